@@ -0,0 +1,153 @@
+package appwrite
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Account service
+type Account struct {
+	client Client
+}
+
+func NewAccount(clt Client) Account {
+	service := Account{
+		client: clt,
+	}
+
+	return service
+}
+
+// Get get the currently logged in user.
+func (srv *Account) Get() (map[string]interface{}, error) {
+	path := "/account"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// UpdateName update currently logged in user account name.
+func (srv *Account) UpdateName(Name string) (map[string]interface{}, error) {
+	path := "/account/name"
+
+	params := map[string]interface{}{
+		"name": Name,
+	}
+
+	return srv.client.Call("PATCH", path, nil, params)
+}
+
+// GetPrefs get the preferences as a key-value object for the currently
+// logged in user.
+func (srv *Account) GetPrefs() (map[string]interface{}, error) {
+	path := "/account/prefs"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// UpdatePrefs update currently logged in user account preferences. The
+// object you pass is stored as is, and replaces any previous value.
+func (srv *Account) UpdatePrefs(Prefs interface{}) (map[string]interface{}, error) {
+	path := "/account/prefs"
+
+	params := map[string]interface{}{
+		"prefs": Prefs,
+	}
+
+	return srv.client.Call("PATCH", path, nil, params)
+}
+
+// CreateEmailSession allow the user to login into their account by providing
+// a valid email and password combination. This route will create a new
+// session for the user.
+func (srv *Account) CreateEmailSession(Email string, Password string) (map[string]interface{}, error) {
+	path := "/account/sessions/email"
+
+	params := map[string]interface{}{
+		"email":    Email,
+		"password": Password,
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// CreateEmailPasswordSessionAndStore login the user with an email and
+// password combination, like CreateEmailSession, and sets the secret of the
+// new session on the client with SetSession, so that the following requests
+// of the client and its services are made as that user. The secret is only
+// returned to requests made with an API key.
+func (srv *Account) CreateEmailPasswordSessionAndStore(Email string, Password string) (map[string]interface{}, error) {
+	session, err := srv.CreateEmailSession(Email, Password)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, _ := session["secret"].(string)
+	if secret == "" {
+		return session, errors.New("session secret not returned; set an API key to receive it")
+	}
+	srv.client.SetSession(secret)
+
+	return session, nil
+}
+
+// CreateJWT use this endpoint to create a JSON Web Token. You can use the
+// resulting JWT to authenticate on behalf of the current user when working
+// with the Appwrite server-side API and SDKs. The JWT secret is valid for 15
+// minutes from its creation and will be invalid if the user will logout in
+// that time frame.
+func (srv *Account) CreateJWT() (map[string]interface{}, error) {
+	path := "/account/jwts"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// CreateOAuth2Token returns the URL that starts the OAuth2 login flow with
+// the given provider. The URL is meant to be opened in the user's browser,
+// which is redirected to success or failure once the flow completes. The
+// project ID is added to the URL since browsers can't send the project
+// header.
+func (srv *Account) CreateOAuth2Token(Provider string, Success string, Failure string, Scopes []string) string {
+	r := strings.NewReplacer("{provider}", url.PathEscape(Provider))
+	path := r.Replace("/account/sessions/oauth2/{provider}")
+
+	query := url.Values{}
+	if project := srv.client.header("X-Appwrite-Project"); project != "" {
+		query.Set("project", project)
+	}
+	if Success != "" {
+		query.Set("success", Success)
+	}
+	if Failure != "" {
+		query.Set("failure", Failure)
+	}
+	for _, scope := range Scopes {
+		query.Add("scopes[]", scope)
+	}
+
+	return joinURL(srv.client.baseURL(), path) + "?" + query.Encode()
+}
+
+// GetPrefsInto decodes the preferences of the currently logged in user into
+// out, letting them be read into a struct.
+func GetPrefsInto[T any](srv *Account, out *T) error {
+	prefs, err := srv.GetPrefs()
+	if err != nil {
+		return err
+	}
+
+	return Unmarshal(prefs, out)
+}
+
+// UpdatePrefsFrom replaces the preferences of the currently logged in user
+// with prefs, typically a struct encoded through its json tags, and returns
+// the updated user.
+func UpdatePrefsFrom[T any](srv *Account, prefs T) (map[string]interface{}, error) {
+	return srv.UpdatePrefs(prefs)
+}
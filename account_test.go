@@ -0,0 +1,152 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestAccount(t *testing.T) {
+	clt, last := recordRequests(t, `{"$id":"u1"}`)
+	srv := NewAccount(clt)
+
+	tests := []struct {
+		name   string
+		call   func() (map[string]interface{}, error)
+		method string
+		path   string
+		body   map[string]interface{}
+	}{
+		{"Get", srv.Get, "GET", "/v1/account", nil},
+		{"UpdateName", func() (map[string]interface{}, error) { return srv.UpdateName("Ada") }, "PATCH", "/v1/account/name", map[string]interface{}{"name": "Ada"}},
+		{"CreateEmailSession", func() (map[string]interface{}, error) { return srv.CreateEmailSession("a@b.c", "password") }, "POST", "/v1/account/sessions/email", map[string]interface{}{"email": "a@b.c", "password": "password"}},
+		{"CreateJWT", srv.CreateJWT, "POST", "/v1/account/jwts", nil},
+		{"GetPrefs", srv.GetPrefs, "GET", "/v1/account/prefs", nil},
+		{"UpdatePrefs", func() (map[string]interface{}, error) {
+			return srv.UpdatePrefs(map[string]interface{}{"theme": "dark"})
+		}, "PATCH", "/v1/account/prefs", map[string]interface{}{"prefs": map[string]interface{}{"theme": "dark"}}},
+	}
+	for _, test := range tests {
+		result, err := test.call()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if result["$id"] != "u1" {
+			t.Fatalf("%s: got %v", test.name, result)
+		}
+
+		req := last()
+		if req.Method != test.method || req.Path != test.path {
+			t.Errorf("%s: sent %s %s, want %s %s", test.name, req.Method, req.Path, test.method, test.path)
+		}
+		if len(req.Body) != 0 || len(test.body) != 0 {
+			if !reflect.DeepEqual(req.Body, test.body) {
+				t.Errorf("%s: sent %v, want %v", test.name, req.Body, test.body)
+			}
+		}
+	}
+}
+
+func TestAccountCreateOAuth2Token(t *testing.T) {
+	clt := NewClient(WithProject("test"), WithEndpoint("https://cloud.appwrite.io/v1"))
+	srv := NewAccount(clt)
+
+	got := srv.CreateOAuth2Token("google", "https://example.com/ok?from=login", "https://example.com/fail", []string{"email", "profile"})
+	want := "https://cloud.appwrite.io/v1/account/sessions/oauth2/google?failure=https%3A%2F%2Fexample.com%2Ffail&project=test&scopes%5B%5D=email&scopes%5B%5D=profile&success=https%3A%2F%2Fexample.com%2Fok%3Ffrom%3Dlogin"
+	if got != want {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestPrefsRoundTrip(t *testing.T) {
+	type notifications struct {
+		Email bool     `json:"email"`
+		Muted []string `json:"muted"`
+	}
+	type prefs struct {
+		Theme         string        `json:"theme"`
+		Notifications notifications `json:"notifications"`
+	}
+
+	var mu sync.Mutex
+	stored := []byte(`{}`)
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/account/prefs" {
+			t.Errorf("got path %q", r.URL.Path)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == "PATCH" {
+			var body struct {
+				Prefs json.RawMessage `json:"prefs"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			stored = body.Prefs
+			writeJSON(w, http.StatusOK, fmt.Sprintf(`{"$id":"u1","prefs":%s}`, stored))
+			return
+		}
+		writeJSON(w, http.StatusOK, string(stored))
+	})
+	srv := NewAccount(clt)
+
+	want := prefs{Theme: "dark", Notifications: notifications{Email: true, Muted: []string{"marketing"}}}
+	if _, err := UpdatePrefsFrom(&srv, want); err != nil {
+		t.Fatal(err)
+	}
+
+	var got prefs
+	if err := GetPrefsInto(&srv, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateEmailPasswordSessionAndStore(t *testing.T) {
+	clt, last := recordRequests(t, `{"$id":"s1","userId":"u1","secret":"session-secret"}`)
+	clt.SetKey("api-key")
+	srv := NewAccount(clt)
+
+	session, err := srv.CreateEmailPasswordSessionAndStore("a@b.c", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session["$id"] != "s1" {
+		t.Fatalf("got session %v", session)
+	}
+	if req := last(); req.Body["email"] != "a@b.c" || req.Header.Get("X-Appwrite-Session") != "" {
+		t.Fatalf("sent %v with session %q", req.Body, req.Header.Get("X-Appwrite-Session"))
+	}
+
+	if _, err := srv.Get(); err != nil {
+		t.Fatal(err)
+	}
+	if got := last().Header.Get("X-Appwrite-Session"); got != "session-secret" {
+		t.Fatalf("sent session %q", got)
+	}
+	if _, err := clt.Call("GET", "/account/prefs", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := last().Header.Get("X-Appwrite-Session"); got != "session-secret" {
+		t.Fatalf("the client sent session %q", got)
+	}
+}
+
+func TestCreateEmailPasswordSessionAndStoreWithoutSecret(t *testing.T) {
+	clt, last := recordRequests(t, `{"$id":"s1","userId":"u1","secret":""}`)
+	srv := NewAccount(clt)
+
+	if _, err := srv.CreateEmailPasswordSessionAndStore("a@b.c", "password"); err == nil {
+		t.Fatal("expected an error without a session secret")
+	}
+	srv.Get()
+	if got := last().Header.Get("X-Appwrite-Session"); got != "" {
+		t.Fatalf("sent session %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+package appwrite
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// pngHeader is the start of a PNG image, holding bytes that aren't valid
+// UTF-8 or JSON
+var pngHeader = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff}
+
+// recordImageRequests starts a test server answering every request with
+// pngHeader, and returning the path and query of the last request
+func recordImageRequests(t *testing.T) (Client, *string, *url.Values) {
+	t.Helper()
+
+	var path string
+	var query url.Values
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query()
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(pngHeader)
+	})
+	return clt, &path, &query
+}
+
+func TestAvatarsGetInitials(t *testing.T) {
+	clt, path, query := recordImageRequests(t)
+	srv := NewAvatars(clt)
+
+	image, err := srv.GetInitials("Ada Lovelace", 100, 50, "ff0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(image, pngHeader) {
+		t.Fatalf("got %v", image)
+	}
+	want := url.Values{"name": {"Ada Lovelace"}, "width": {"100"}, "height": {"50"}, "background": {"ff0000"}}
+	if *path != "/v1/avatars/initials" || query.Encode() != want.Encode() {
+		t.Fatalf("sent %s?%s", *path, query.Encode())
+	}
+}
+
+func TestAvatarsGetQR(t *testing.T) {
+	clt, path, query := recordImageRequests(t)
+	srv := NewAvatars(clt)
+
+	image, err := srv.GetQR("https://appwrite.io", 300, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(image, pngHeader) {
+		t.Fatalf("got %v", image)
+	}
+	want := url.Values{"text": {"https://appwrite.io"}, "size": {"300"}, "margin": {"2"}, "download": {"0"}}
+	if *path != "/v1/avatars/qr" || query.Encode() != want.Encode() {
+		t.Fatalf("sent %s?%s", *path, query.Encode())
+	}
+}
+
+func TestAvatarsGetImage(t *testing.T) {
+	clt, path, query := recordImageRequests(t)
+	srv := NewAvatars(clt)
+
+	image, err := srv.GetImage("https://example.com/a.png?size=large&v=2", 64, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(image, pngHeader) {
+		t.Fatalf("got %v", image)
+	}
+	if *path != "/v1/avatars/image" || query.Get("url") != "https://example.com/a.png?size=large&v=2" {
+		t.Fatalf("sent %s?%s", *path, query.Encode())
+	}
+}
+
+func TestAvatarsGetFavicon(t *testing.T) {
+	clt, path, query := recordImageRequests(t)
+	srv := NewAvatars(clt)
+
+	image, err := srv.GetFavicon("https://example.com/page?a=b&c=d#top")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(image, pngHeader) {
+		t.Fatalf("got %v", image)
+	}
+	want := url.Values{"url": {"https://example.com/page?a=b&c=d#top"}}
+	if *path != "/v1/avatars/favicon" || !reflect.DeepEqual(*query, want) {
+		t.Fatalf("sent %s?%s", *path, query.Encode())
+	}
+}
+
+func TestAvatarsIcons(t *testing.T) {
+	clt, path, query := recordImageRequests(t)
+	srv := NewAvatars(clt)
+
+	tests := []struct {
+		name string
+		call func() ([]byte, error)
+		path string
+	}{
+		{"GetBrowser", func() ([]byte, error) { return srv.GetBrowser("ch", 32, 32, 90) }, "/v1/avatars/browsers/ch"},
+		{"GetCreditCard", func() ([]byte, error) { return srv.GetCreditCard("visa", 32, 32, 90) }, "/v1/avatars/credit-cards/visa"},
+		{"GetFlag", func() ([]byte, error) { return srv.GetFlag("fr", 32, 32, 90) }, "/v1/avatars/flags/fr"},
+	}
+	want := url.Values{"width": {"32"}, "height": {"32"}, "quality": {"90"}}
+	for _, test := range tests {
+		image, err := test.call()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !bytes.Equal(image, pngHeader) {
+			t.Errorf("%s: got %v", test.name, image)
+		}
+		if *path != test.path || !reflect.DeepEqual(*query, want) {
+			t.Errorf("%s: sent %s?%s", test.name, *path, query.Encode())
+		}
+	}
+}
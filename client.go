@@ -2,109 +2,878 @@ package appwrite
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
-	"log"
+	"mime"
 	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
-// Client is the client struct to access Appwrite services
+const (
+	// SDKVersion is the version of this SDK, reported in the User-Agent header
+	SDKVersion = "0.1.0"
+
+	// ResponseFormat is the Appwrite response format this SDK is built against
+	ResponseFormat = "1.5.0"
+
+	// DefaultEndpoint is the Appwrite Cloud endpoint used by NewClient until
+	// another endpoint is set
+	DefaultEndpoint = "https://cloud.appwrite.io/v1"
+
+	// DefaultAPIVersion is the API version added to endpoints set without
+	// one
+	DefaultAPIVersion = "v1"
+)
+
+// DefaultMaxResponseBytes is the largest response body the Client reads
+// into memory unless SetMaxResponseBytes says otherwise
+const DefaultMaxResponseBytes = 50 * 1024 * 1024
+
+// ErrResponseTooLarge is returned when a response body exceeds the limit set
+// with SetMaxResponseBytes
+var ErrResponseTooLarge = errors.New("response body exceeds the maximum size")
+
+// ErrNotModified is returned by CallStream when the server answers 304 Not
+// Modified to a conditional request, meaning the cached copy is still valid
+var ErrNotModified = errors.New("not modified")
+
+// ErrProjectNotSet is returned by calls sent without a project ID, unless
+// anonymous calls are allowed with SetAnonymous
+var ErrProjectNotSet = errors.New("project id not set; call SetProject")
+
+// userAgent identifies requests sent by this SDK
+var userAgent = "AppwriteGoSDK/" + SDKVersion + " (" + runtime.GOOS + "; " + runtime.GOARCH + ")"
+
+// versionSegment matches an API version path segment, such as v1
+var versionSegment = regexp.MustCompile(`^v[0-9]+(\.[0-9]+)*$`)
+
+// clientInitMu guards the lazy creation of the mutex of zero-value Clients
+var clientInitMu sync.Mutex
+
+// Client is the client struct to access Appwrite services. Once configured,
+// a Client is safe for concurrent use, and setters may be called while calls
+// are in flight
 type Client struct {
-	client     *http.Client
+	// mu guards the fields below, and is shared by copies of a Client
+	// along with the headers map
+	mu *sync.RWMutex
+
+	headers map[string]string
+
+	// http holds the *http.Client requests are sent with, and is shared by
+	// copies of a Client so that services use one connection pool
+	http *httpState
+
+	// cfg holds the settings read by each call, see config
+	cfg clientConfig
+
+	// keys holds the API keys requests rotate through, and is shared by
+	// copies of a Client so that they follow SetKeys
+	keys *keyRing
+
+	// devKeyWarning logs the warning about the dev key once, and is shared
+	// by copies of a Client so that services warn only once too
+	devKeyWarning *sync.Once
+}
+
+// clientConfig holds the settings of a Client read by each call
+type clientConfig struct {
 	endpoint   string
-	headers    map[string]string
-	selfSigned bool
+	apiVersion string
+
+	retryAttempts int
+	retryDelay    time.Duration
+	rateLimitWait time.Duration
+
+	logger      func(msg string)
+	debugLogger func(DebugEvent)
+	compression bool
+	anonymous   bool
+
+	maxResponseBytes int64
+	timings          bool
+
+	responseFormatFallback string
+
+	requestInterceptors  []func(*http.Request)
+	responseInterceptors []func(*http.Response)
 }
 
-// SetEndpoint sets the default endpoint to which the Client connects to
-func (clt *Client) SetEndpoint(endpoint string) {
-	clt.endpoint = endpoint
+// Response is an API response returned by CallWithResponse
+type Response struct {
+	// StatusCode is the HTTP status of the successful response, telling
+	// apart for instance a created resource (201) from a read one (200)
+	StatusCode int
+	Headers    http.Header
+	Body       map[string]interface{}
+	RateLimit  RateLimit
+
+	// Timings is set when enabled with SetTimings
+	Timings *Timings
+}
+
+// SetEndpoint sets the default endpoint to which the Client connects to. The
+// endpoint must be an absolute URL such as DefaultEndpoint, otherwise an
+// error is returned and the current endpoint is kept
+func (clt *Client) SetEndpoint(endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid endpoint %q: must be an absolute URL such as %s", endpoint, DefaultEndpoint)
+	}
+	clt.configure(func(cfg *clientConfig) {
+		cfg.endpoint = endpoint
+	})
+	return nil
+}
+
+// SetAPIVersion sets the API version added to the endpoint when it has no
+// version segment, DefaultAPIVersion by default. An endpoint ending in a
+// version, such as DefaultEndpoint, is used as is
+func (clt *Client) SetAPIVersion(version string) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.apiVersion = strings.Trim(version, "/")
+	})
+}
+
+// baseURL returns the endpoint requests are sent to, ending in an API
+// version
+func (clt *Client) baseURL() string {
+	cfg := clt.config()
+	endpoint := strings.TrimRight(cfg.endpoint, "/")
+	if versionSegment.MatchString(endpoint[strings.LastIndex(endpoint, "/")+1:]) {
+		return endpoint
+	}
+
+	version := cfg.apiVersion
+	if version == "" {
+		version = DefaultAPIVersion
+	}
+	return endpoint + "/" + version
 }
 
 // SetSelfSigned sets the condition that specify if the Client should allow connections to a server using a self-signed certificate
 func (clt *Client) SetSelfSigned(status bool) {
-	clt.selfSigned = status
+	mu := clt.lock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	clt.http.selfSigned = status
+	clt.http.resetOwnClient()
+}
+
+// SetHTTPClient sets the *http.Client used to send requests, giving full
+// control over its transport. SetSelfSigned has no effect on an injected
+// client, whose TLS settings belong to its transport, while SetTimeout
+// updates its Timeout when called after SetHTTPClient. Passing nil restores
+// the Client's own *http.Client
+func (clt *Client) SetHTTPClient(client *http.Client) {
+	mu := clt.lock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	clt.http.client = client
+	clt.http.custom = client != nil
+}
+
+// SetTimeout sets the maximum duration the Client waits for a request to
+// complete. A zero duration means no timeout
+func (clt *Client) SetTimeout(timeout time.Duration) {
+	mu := clt.lock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	clt.http.timeout = timeout
+	if clt.http.client != nil {
+		// Swap in a copy so requests in flight keep their client untouched
+		client := *clt.http.client
+		client.Timeout = timeout
+		clt.http.client = &client
+	}
 }
 
 // AddHeader add a new custom header that the Client should send on each request
 func (clt *Client) AddHeader(key string, value string) {
-	clt.headers[key] = value
+	clt.setHeader(key, value)
+}
+
+// Headers returns a copy of the headers the Client sends on each request,
+// with the values of credentials such as the API key and JWT redacted
+func (clt *Client) Headers() map[string]string {
+	headers := clt.headersSnapshot()
+	for name := range headers {
+		if isSensitive(name) || isSensitiveHeader(name) {
+			headers[name] = redacted
+		}
+	}
+	return headers
 }
 
 // Your project ID
 func (clt *Client) SetProject(value string) {
-	clt.headers["X-Appwrite-Project"] = value
+	clt.setHeader("X-Appwrite-Project", value)
 }
 
 // Your secret API key
 func (clt *Client) SetKey(value string) {
-	clt.headers["X-Appwrite-Key"] = value
+	clt.SetKeys([]string{value})
+}
+
+// SetKeys sets several secret API keys, used in turn by successive
+// requests to spread the load over their rate limits. A single key is used
+// for every request, like SetKey
+func (clt *Client) SetKeys(keys []string) {
+	mu := clt.lock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	if clt.headers == nil {
+		clt.headers = make(map[string]string)
+	}
+
+	clt.keys.keys = nil
+	switch len(keys) {
+	case 0:
+		delete(clt.headers, "X-Appwrite-Key")
+	case 1:
+		clt.headers["X-Appwrite-Key"] = keys[0]
+	default:
+		clt.headers["X-Appwrite-Key"] = keys[0]
+		clt.keys.keys = append([]string(nil), keys...)
+	}
+}
+
+// SetKeyWithName sets a named API key, such as a "standard" or "dynamic"
+// key, sent as the name followed by an underscore and the secret. An empty
+// name sets the secret as is, like SetKey
+func (clt *Client) SetKeyWithName(name string, secret string) {
+	if name == "" {
+		clt.SetKey(secret)
+		return
+	}
+	clt.SetKey(name + "_" + secret)
+}
+
+// SetMaxResponseBytes sets the largest response body the Client reads into
+// memory, DefaultMaxResponseBytes by default. Bodies returned by CallStream
+// are not limited. A limit of zero or less restores the default
+func (clt *Client) SetMaxResponseBytes(limit int64) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.maxResponseBytes = limit
+	})
+}
+
+// responseLimit returns the largest response body the Client reads
+func (clt *Client) responseLimit() int64 {
+	limit := clt.config().maxResponseBytes
+	if limit <= 0 {
+		return DefaultMaxResponseBytes
+	}
+	return limit
+}
+
+// SetAnonymous allows calls to be sent without a project ID, for endpoints
+// that don't need one. By default such calls fail with ErrProjectNotSet
+// before reaching the server
+func (clt *Client) SetAnonymous(status bool) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.anonymous = status
+	})
+}
+
+// SetJWT sets a JWT created with account.createJWT, scoping requests to the
+// user who owns it the way a client SDK would. When an API key is also set
+// the server favors the user scope of the JWT
+func (clt *Client) SetJWT(value string) {
+	clt.setHeader("X-Appwrite-JWT", value)
+}
+
+// SetSession sets the secret of a user session, authenticating requests as
+// that user. To replay the session cookies set by the server instead, inject
+// an *http.Client with a cookie jar using SetHTTPClient
+func (clt *Client) SetSession(value string) {
+	clt.setHeader("X-Appwrite-Session", value)
 }
 
 func (clt *Client) SetLocale(value string) {
-	clt.headers["X-Appwrite-Locale"] = value
+	clt.setHeader("X-Appwrite-Locale", value)
 }
 
-func (clt *Client) SetMode(value string) {
-	clt.headers["X-Appwrite-Mode"] = value
+// SetForwardedFor sets the IP address of the end user every request is made
+// on behalf of, sent in the X-Forwarded-For header. Use ContextWithForwarded
+// to set it for a single call
+func (clt *Client) SetForwardedFor(value string) {
+	clt.setHeader(ForwardedForHeader, value)
+}
+
+// SetForwardedUserAgent sets the user agent of the end user every request is
+// made on behalf of, sent in the X-Forwarded-User-Agent header
+func (clt *Client) SetForwardedUserAgent(value string) {
+	clt.setHeader(ForwardedUserAgentHeader, value)
+}
+
+// Mode is an API mode, sent in the X-Appwrite-Mode header
+type Mode string
+
+const (
+	// ModeDefault is the regular API mode
+	ModeDefault Mode = "default"
+
+	// ModeAdmin gives access to all the project resources, see
+	// [API modes](/docs/admin)
+	ModeAdmin Mode = "admin"
+)
+
+// SetMode sets the API mode from its name, returning an error and leaving
+// the mode unchanged when the name is not a known Mode
+func (clt *Client) SetMode(value string) error {
+	switch mode := Mode(value); mode {
+	case ModeDefault, ModeAdmin:
+		clt.SetModeTyped(mode)
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q", value)
+	}
+}
+
+// SetModeTyped sets the API mode
+func (clt *Client) SetModeTyped(mode Mode) {
+	clt.setHeader("X-Appwrite-Mode", string(mode))
+}
+
+// SetResponseFormat pins the Appwrite response format version, overriding
+// the ResponseFormat the SDK is built against
+func (clt *Client) SetResponseFormat(value string) {
+	clt.setHeader("X-Appwrite-Response-Format", value)
+}
+
+// AddRequestInterceptor adds a function called with every request right
+// before it is sent, in the order interceptors were added
+func (clt *Client) AddRequestInterceptor(interceptor func(*http.Request)) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.requestInterceptors = append(cfg.requestInterceptors, interceptor)
+	})
+}
+
+// AddResponseInterceptor adds a function called with every response right
+// after it is received, in the order interceptors were added
+func (clt *Client) AddResponseInterceptor(interceptor func(*http.Response)) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.responseInterceptors = append(cfg.responseInterceptors, interceptor)
+	})
+}
+
+// setHeader stores a client header, creating the headers map of a zero-value
+// Client on first use
+func (clt *Client) setHeader(key string, value string) {
+	mu := clt.lock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	if clt.headers == nil {
+		clt.headers = make(map[string]string)
+	}
+	clt.headers[key] = value
+}
+
+// keyRing holds API keys used in turn, when several keys are set
+type keyRing struct {
+	keys []string
+	next uint64
+}
+
+// nextKey returns the API key of the next request when rotating through
+// several keys, or an empty string otherwise
+func (clt *Client) nextKey() string {
+	mu := clt.lock()
+	mu.RLock()
+	ring := clt.keys
+	keys := ring.keys
+	mu.RUnlock()
+
+	if len(keys) == 0 {
+		return ""
+	}
+	n := atomic.AddUint64(&ring.next, 1) - 1
+	return keys[n%uint64(len(keys))]
+}
+
+// header returns the value of a client header
+func (clt *Client) header(key string) string {
+	mu := clt.lock()
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return clt.headers[key]
+}
+
+// headersSnapshot returns a copy of the client headers
+func (clt *Client) headersSnapshot() map[string]string {
+	mu := clt.lock()
+	mu.RLock()
+	defer mu.RUnlock()
+
+	headers := make(map[string]string, len(clt.headers))
+	for key, val := range clt.headers {
+		headers[key] = val
+	}
+	return headers
+}
+
+// httpClient returns the *http.Client requests are sent with
+func (clt *Client) httpClient() *http.Client {
+	mu := clt.lock()
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return clt.http.client
+}
+
+// config returns a copy of the settings of the Client. The interceptor
+// slices are shared with the Client, which only ever appends to them
+func (clt *Client) config() clientConfig {
+	mu := clt.lock()
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return clt.cfg
+}
+
+// configure applies change to the settings of the Client
+func (clt *Client) configure(change func(cfg *clientConfig)) {
+	mu := clt.lock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	change(&clt.cfg)
+}
+
+// lock returns the mutex of the Client, creating it on first use for a
+// zero-value Client along with the other state shared by copies. Clients
+// built by NewClient already have one, so only zero-value Clients take
+// clientInitMu, and only until their mutex is set
+func (clt *Client) lock() *sync.RWMutex {
+	mup := (*unsafe.Pointer)(unsafe.Pointer(&clt.mu))
+	if mu := atomic.LoadPointer(mup); mu != nil {
+		return (*sync.RWMutex)(mu)
+	}
+
+	clientInitMu.Lock()
+	defer clientInitMu.Unlock()
+
+	if clt.mu == nil {
+		clt.http = &httpState{}
+		clt.keys = &keyRing{}
+		clt.devKeyWarning = &sync.Once{}
+		// Publish the mutex last, so the state above is set for callers
+		// taking the fast path
+		atomic.StorePointer(mup, unsafe.Pointer(&sync.RWMutex{}))
+	}
+	return clt.mu
+}
+
+// Clone returns a copy of the Client with its own headers and settings, so
+// that changing the copy, for instance its project or JWT, leaves the
+// original untouched. Both clients share the same *http.Client and its
+// connection pool
+func (clt *Client) Clone() Client {
+	clt.ensureClientInitialized()
+
+	mu := clt.lock()
+	mu.RLock()
+	defer mu.RUnlock()
+
+	clone := *clt
+	clone.mu = &sync.RWMutex{}
+	state := *clt.http
+	clone.http = &state
+	clone.keys = &keyRing{keys: clt.keys.keys}
+	clone.devKeyWarning = &sync.Once{}
+	clone.headers = make(map[string]string, len(clt.headers))
+	for key, val := range clt.headers {
+		clone.headers[key] = val
+	}
+	clone.cfg.requestInterceptors = append(clt.cfg.requestInterceptors[:0:0], clt.cfg.requestInterceptors...)
+	clone.cfg.responseInterceptors = append(clt.cfg.responseInterceptors[:0:0], clt.cfg.responseInterceptors...)
+
+	return clone
 }
 
 // Call an API using Client
 func (clt *Client) Call(method string, path string, headers map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
+	return clt.CallWithContext(context.Background(), method, path, headers, params)
+}
+
+// CallWithContext calls an API using Client, aborting the request when ctx is
+// cancelled or its deadline expires
+func (clt *Client) CallWithContext(ctx context.Context, method string, path string, headers map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
+	response, err := clt.CallWithResponse(ctx, method, path, headers, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+// CallWithResponse calls an API using Client and returns the decoded body
+// together with the response status code and headers
+func (clt *Client) CallWithResponse(ctx context.Context, method string, path string, headers map[string]interface{}, params map[string]interface{}) (*Response, error) {
+	var trace *timingTrace
+	if clt.config().timings {
+		ctx, trace = newTimingTrace(ctx)
+	}
+
+	response, err := clt.send(ctx, method, path, headers, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := clt.readResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	if trace != nil {
+		result.Timings = trace.finish()
+	}
+	return result, nil
+}
+
+// readResponse decodes the JSON body of response and closes it
+func (clt *Client) readResponse(response *http.Response) (*Response, error) {
+	defer response.Body.Close()
+
+	jsonResponse, err := parseJSONResponse(response, clt.responseLimit())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode: response.StatusCode,
+		Headers:    response.Header,
+		Body:       jsonResponse,
+		RateLimit:  parseRateLimit(response.Header),
+	}, nil
+}
+
+// CallStream calls an API using Client and returns the raw response body for
+// binary endpoints such as file downloads. The caller must close the body.
+// To revalidate a cached copy, pass its ETag in an If-None-Match header:
+// ErrNotModified is returned, along with the response headers, when the
+// server answers 304 Not Modified
+func (clt *Client) CallStream(method string, path string, headers map[string]interface{}, params map[string]interface{}) (io.ReadCloser, http.Header, error) {
+	return clt.CallStreamWithContext(context.Background(), method, path, headers, params)
+}
+
+// CallStreamWithContext is CallStream aborting the request when ctx is
+// cancelled or its deadline expires
+func (clt *Client) CallStreamWithContext(ctx context.Context, method string, path string, headers map[string]interface{}, params map[string]interface{}) (io.ReadCloser, http.Header, error) {
+	response, err := clt.send(ctx, method, path, headers, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if response.StatusCode == http.StatusNotModified {
+		response.Body.Close()
+		return nil, response.Header, ErrNotModified
+	}
+
+	if response.StatusCode >= 400 {
+		defer response.Body.Close()
+		_, err := readResponseBody(response, clt.responseLimit())
+		return nil, response.Header, err
+	}
+
+	return response.Body, response.Header, nil
+}
+
+// callBytes calls a binary endpoint and returns the whole response body
+func (clt *Client) callBytes(method string, path string, headers map[string]interface{}, params map[string]interface{}) ([]byte, error) {
+	body, _, err := clt.CallStream(method, path, headers, params)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return readLimited(body, clt.responseLimit())
+}
+
+// send normalizes method and sends the call, returning the raw response. The
+// caller is responsible for closing the response body
+func (clt *Client) send(ctx context.Context, method string, path string, headers map[string]interface{}, params map[string]interface{}) (*http.Response, error) {
+	method, err := normalizeMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
 	clt.ensureClientInitialized()
 
-	urlPath := clt.endpoint + path
-	isGet := strings.ToUpper(method) == "GET"
+	// Files are read by each attempt, so resent requests must rewind them
+	params, rewind, err := rewindableFiles(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.do(ctx, method, func() (*http.Request, error) {
+		if err := rewind(); err != nil {
+			return nil, err
+		}
+		return clt.newRequest(ctx, method, path, headers, params)
+	})
+}
+
+// normalizeMethod returns the canonical uppercase form of an HTTP method,
+// rejecting methods the API doesn't use
+func normalizeMethod(method string) (string, error) {
+	switch upper := strings.ToUpper(method); upper {
+	case "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS":
+		return upper, nil
+	default:
+		return "", fmt.Errorf("unsupported method %q", method)
+	}
+}
+
+// BuildURL returns the URL a call would be sent to, including the encoded
+// query of GET calls
+func (clt *Client) BuildURL(method string, path string, params map[string]interface{}) (string, error) {
+	method, err := normalizeMethod(method)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(method, joinURL(clt.baseURL(), path), nil)
+	if err != nil {
+		return "", err
+	}
+
+	if method == "GET" {
+		updateQueryParameters(req, params)
+	}
+
+	return req.URL.String(), nil
+}
+
+// newRequest builds the HTTP request for a single attempt of a call
+func (clt *Client) newRequest(ctx context.Context, method string, path string, headers map[string]interface{}, params map[string]interface{}) (*http.Request, error) {
+	urlPath := joinURL(clt.baseURL(), path)
+	isGet := method == "GET"
 
 	var reqBody io.Reader
+	contentType := "application/json"
+	contentEncoding := ""
 	if !isGet {
-		reqBody = prepareRequestBody(params)
+		if hasInputFile(params) {
+			body, multipartType, err := prepareMultipartBody(params)
+			if err != nil {
+				return nil, err
+			}
+			reqBody = body
+			contentType = multipartType
+		} else if isFormRequest(headers) {
+			reqBody = strings.NewReader(encodeForm(params))
+			contentType = formContentType
+		} else {
+			reqBody, contentEncoding = prepareRequestBody(params, clt.config().compression)
+		}
 	}
 
-	req, err := http.NewRequest(method, urlPath, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, urlPath, reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	setHeaders(req, clt.headers, headers)
+	if err := clt.setRequestHeaders(ctx, req, headers); err != nil {
+		return nil, err
+	}
 
 	if isGet {
 		updateQueryParameters(req, params)
 	} else {
 		// Set the Content-Type header for non-GET requests
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
+		if contentEncoding != "" {
+			req.Header.Set("Content-Encoding", contentEncoding)
+		}
 	}
 
-	response, err := clt.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer response.Body.Close()
+	clt.logRequest(req, params)
 
-	jsonResponse, err := parseJSONResponse(response)
-	if err != nil {
-		return nil, err
+	return req, nil
+}
+
+// setRequestHeaders sets the default, client and call headers on req
+func (clt *Client) setRequestHeaders(ctx context.Context, req *http.Request, headers map[string]interface{}) error {
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Appwrite-Response-Format", ResponseFormat)
+	clientHeaders := clt.headersSnapshot()
+	if key := clt.nextKey(); key != "" {
+		clientHeaders["X-Appwrite-Key"] = key
+	}
+	setHeaders(req, clientHeaders, headers)
+	setForwardedHeaders(ctx, req)
+	if req.Header.Get(DevKeyHeader) != "" {
+		clt.warnDevKey()
 	}
+	if !clt.config().anonymous && req.Header.Get("X-Appwrite-Project") == "" {
+		return ErrProjectNotSet
+	}
+	return nil
+}
 
-	return jsonResponse, nil
+// do sends the request built by newReq, retrying it when the retry policy
+// allows. The caller is responsible for closing the returned response body
+func (clt *Client) do(ctx context.Context, method string, newReq func() (*http.Request, error)) (*http.Response, error) {
+	cfg := clt.config()
+	attempt := 1
+	var rateLimitWaited time.Duration
+	fellBack := false
+
+	for {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		if fellBack {
+			req.Header.Set("X-Appwrite-Response-Format", cfg.responseFormatFallback)
+		}
+
+		for _, interceptor := range cfg.requestInterceptors {
+			interceptor(req)
+		}
+
+		response, err := clt.httpClient().Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		if err := decodeResponseBody(response); err != nil {
+			response.Body.Close()
+			return nil, err
+		}
+
+		for _, interceptor := range cfg.responseInterceptors {
+			interceptor(response)
+		}
+
+		clt.logDebug(req, response)
+
+		if !fellBack && clt.isResponseFormatUnsupported(response) {
+			drainBody(response.Body)
+			fellBack = true
+			continue
+		}
+
+		if response.StatusCode == http.StatusTooManyRequests {
+			if delay, ok := clt.rateLimitDelay(ctx, response, rateLimitWaited); ok {
+				drainBody(response.Body)
+				if err := sleepContext(ctx, delay); err != nil {
+					return nil, err
+				}
+				rateLimitWaited += delay
+				continue
+			}
+		}
+
+		attempts := 1
+		if cfg.retryAttempts > 1 && canRetry(req) {
+			attempts = cfg.retryAttempts
+		}
+		if attempt >= attempts || !isRetryableStatus(response.StatusCode) {
+			return response, nil
+		}
+
+		delay := retryDelay(response, cfg.retryDelay, attempt)
+		drainBody(response.Body)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+		attempt++
+	}
 }
 
 func (clt *Client) ensureClientInitialized() {
-	if clt.client == nil {
+	if clt.httpClient() != nil {
+		return
+	}
+
+	mu := clt.lock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	if clt.http.client == nil {
 		// Create HTTP client if it's not initialized
-		clt.client = &http.Client{}
+		clt.http.client = &http.Client{
+			Timeout:   clt.http.timeout,
+			Transport: clt.http.newTransport(),
+		}
 	}
 }
 
-func prepareRequestBody(params map[string]interface{}) io.Reader {
-	jsonData, err := json.Marshal(params)
+// joinURL appends path to endpoint with exactly one slash between them
+func joinURL(endpoint string, path string) string {
+	endpoint = strings.TrimRight(endpoint, "/")
+	path = strings.TrimLeft(path, "/")
+	if path == "" {
+		return endpoint
+	}
+	return endpoint + "/" + path
+}
+
+// prepareRequestBody encodes params as JSON, leaving out nil params and
+// gzipping bodies larger than compressionThreshold when compress is set. It
+// returns the body along with its Content-Encoding, if any
+func prepareRequestBody(params map[string]interface{}, compress bool) (io.Reader, string) {
+	jsonData, err := json.Marshal(omitNilParams(params))
 	if err != nil {
 		// Handle the error
-		return nil
+		return nil, ""
 	}
-	log.Println(string(jsonData))
-	return bytes.NewReader(jsonData)
+	if compress && len(jsonData) > compressionThreshold {
+		if compressed, err := gzipData(jsonData); err == nil {
+			return bytes.NewReader(compressed), "gzip"
+		}
+	}
+	return bytes.NewReader(jsonData), ""
+}
+
+// formContentType is the Content-Type of form-urlencoded bodies
+const formContentType = "application/x-www-form-urlencoded"
+
+// isFormRequest reports whether the call headers ask for a form-urlencoded
+// body rather than JSON
+func isFormRequest(headers map[string]interface{}) bool {
+	for key, val := range headers {
+		if strings.EqualFold(key, "Content-Type") {
+			mediaType, _, _ := mime.ParseMediaType(ToString(val))
+			return mediaType == formContentType
+		}
+	}
+	return false
+}
+
+// encodeForm encodes params as a form-urlencoded body, the same way they
+// are encoded in the query string of GET requests
+func encodeForm(params map[string]interface{}) string {
+	form := url.Values{}
+	for key, val := range params {
+		addQueryParameter(form, key, val)
+	}
+	return form.Encode()
 }
 
 func setHeaders(req *http.Request, clientHeaders map[string]string, customHeaders map[string]interface{}) {
@@ -122,16 +891,112 @@ func setHeaders(req *http.Request, clientHeaders map[string]string, customHeader
 func updateQueryParameters(req *http.Request, params map[string]interface{}) {
 	q := req.URL.Query()
 	for key, val := range params {
-		q.Add(key, ToString(val))
+		addQueryParameter(q, key, val)
 	}
 	req.URL.RawQuery = q.Encode()
 }
 
-func parseJSONResponse(response *http.Response) (map[string]interface{}, error) {
+// addQueryParameter adds val to q, repeating key[] for each element of a
+// slice and flattening nested maps into key[name] entries. Nil values are
+// skipped
+func addQueryParameter(q url.Values, key string, val interface{}) {
+	if isNil(val) {
+		return
+	}
+
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for name, item := range v {
+			addQueryParameter(q, key+"["+name+"]", item)
+		}
+		return
+	case []byte:
+		q.Add(key, string(v))
+		return
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			addQueryParameter(q, key+"[]", rv.Index(i).Interface())
+		}
+		return
+	}
+
+	q.Add(key, ToString(val))
+}
+
+func parseJSONResponse(response *http.Response, limit int64) (map[string]interface{}, error) {
+	body, err := readJSONBody(response, limit)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
 	var jsonResponse map[string]interface{}
-	err := json.NewDecoder(response.Body).Decode(&jsonResponse)
+	err = json.Unmarshal(body, &jsonResponse)
 	if err != nil {
 		return nil, err
 	}
 	return jsonResponse, nil
 }
+
+// readJSONBody reads the JSON body of a successful response, returning a nil
+// body when the response has no content. A body that is not JSON fails
+// with an "unexpected content-type" error
+func readJSONBody(response *http.Response, limit int64) ([]byte, error) {
+	body, err := readResponseBody(response, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	if contentType := response.Header.Get("Content-Type"); !isJSONContentType(contentType) {
+		return nil, errors.New(unexpectedContentTypeMessage(contentType, body))
+	}
+	return body, nil
+}
+
+// readResponseBody reads the body of a successful response, turning error
+// responses into an AppwriteException. Bodies larger than limit bytes fail
+// with ErrResponseTooLarge
+func readResponseBody(response *http.Response, limit int64) ([]byte, error) {
+	body, err := readLimited(response.Body, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 400 {
+		return nil, newAppwriteException(response, body)
+	}
+
+	return body, nil
+}
+
+// isJSONContentType reports whether a response Content-Type holds JSON. A
+// missing Content-Type is assumed to be JSON
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// readLimited reads reader to the end, failing with ErrResponseTooLarge once
+// more than limit bytes were read
+func readLimited(reader io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrResponseTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,806 @@
+package appwrite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer starts a server answering every request with handler, and
+// a Client set up to send requests to it
+func newTestServer(t *testing.T, handler http.HandlerFunc) Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	clt := NewClient(WithProject("test"))
+	if err := clt.SetEndpoint(server.URL); err != nil {
+		t.Fatal(err)
+	}
+	return clt
+}
+
+// writeJSON writes body to w as a JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprint(w, body)
+}
+
+// recordedRequest is a request received by the server of recordRequests
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   map[string]interface{}
+}
+
+// recordRequests starts a test server answering every request with the
+// JSON response, and returning the last request it received
+func recordRequests(t *testing.T, response string) (Client, func() recordedRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var last recordedRequest
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		req := recordedRequest{
+			Method: r.Method,
+			Path:   r.URL.EscapedPath(),
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+		}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &req.Body); err != nil {
+				t.Errorf("invalid JSON body %q: %v", data, err)
+			}
+		}
+
+		mu.Lock()
+		last = req
+		mu.Unlock()
+		writeJSON(w, http.StatusOK, response)
+	})
+
+	return clt, func() recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+}
+
+// recordHeaders starts a test server recording the headers of the last
+// request it received
+func recordHeaders(t *testing.T) (Client, func() http.Header) {
+	t.Helper()
+
+	var mu sync.Mutex
+	headers := http.Header{}
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = r.Header.Clone()
+		mu.Unlock()
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	return clt, func() http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+		return headers
+	}
+}
+
+func TestConcurrentCallsAndSetters(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			clt.AddHeader("X-Test", fmt.Sprint(i))
+			clt.SetKey(fmt.Sprint("key", i))
+			clt.SetKeys([]string{"a", "b"})
+			clt.SetTimeout(time.Minute)
+			clt.SetRetry(2, time.Millisecond)
+			clt.SetLogger(func(string) {})
+			clt.SetDebugLogger(func(DebugEvent) {})
+			clt.SetCompression(i%2 == 0)
+			clt.SetMaxResponseBytes(1 << 20)
+			clt.SetTimings(i%2 == 0)
+			clt.SetRateLimitWait(time.Millisecond)
+			clt.SetResponseFormatFallback("1.4.0")
+			clt.SetAPIVersion("v1")
+			clt.AddRequestInterceptor(func(*http.Request) {})
+			clt.AddResponseInterceptor(func(*http.Response) {})
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestZeroValueClientInitializesOnce(t *testing.T) {
+	var clt Client
+
+	var wg sync.WaitGroup
+	clients := make([]*http.Client, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clt.ensureClientInitialized()
+			clients[i] = clt.httpClient()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, client := range clients {
+		if client != clients[0] {
+			t.Fatal("ensureClientInitialized created several clients")
+		}
+	}
+}
+
+func TestCallWithContextCancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := clt.CallWithContext(ctx, "GET", "/health", nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("returned after %v", elapsed)
+	}
+}
+
+func TestCallWithContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := clt.CallWithContext(ctx, "GET", "/health", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestSetTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	// The first call creates the *http.Client, which must follow later
+	// timeouts too
+	clt.SetTimeout(time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	clt.CallWithContext(ctx, "GET", "/health", nil, nil)
+	cancel()
+
+	clt.SetTimeout(30 * time.Millisecond)
+	start := time.Now()
+	_, err := clt.Call("GET", "/health", nil, nil)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("returned after %v", elapsed)
+	}
+}
+
+func TestCallWithResponseExposesHeaders(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Remaining", "41")
+		writeJSON(w, http.StatusCreated, `{"$id":"a"}`)
+	})
+
+	response, err := clt.CallWithResponse(context.Background(), "POST", "/teams", nil, map[string]interface{}{"name": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != http.StatusCreated || response.Body["$id"] != "a" {
+		t.Fatalf("got %d %v", response.StatusCode, response.Body)
+	}
+	if got := response.Headers.Get("X-Ratelimit-Remaining"); got != "41" {
+		t.Fatalf("got header %q", got)
+	}
+}
+
+func TestCallWithResponseStatusCode(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			writeJSON(w, http.StatusCreated, `{"$id":"a"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"$id":"a"}`)
+	})
+
+	for method, want := range map[string]int{"POST": http.StatusCreated, "GET": http.StatusOK} {
+		response, err := clt.CallWithResponse(context.Background(), method, "/teams", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.StatusCode != want {
+			t.Errorf("%s: got status %d, want %d", method, response.StatusCode, want)
+		}
+	}
+}
+
+// countingTransport is an http.RoundTripper counting the requests it sends
+type countingTransport struct {
+	requests int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.requests, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSetHTTPClient(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	transport := &countingTransport{}
+	client := &http.Client{Transport: transport}
+	clt.SetHTTPClient(client)
+	clt.SetTimeout(time.Minute)
+
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&transport.requests) != 1 {
+		t.Fatal("the injected client was not used")
+	}
+	if got := clt.httpClient(); got.Timeout != time.Minute || got.Transport != transport {
+		t.Fatalf("got timeout %v", got.Timeout)
+	}
+	if client.Timeout != 0 {
+		t.Fatal("SetTimeout changed the injected client in place")
+	}
+
+	clt.SetHTTPClient(nil)
+	clt.ensureClientInitialized()
+	if got := clt.httpClient(); got == nil || got.Transport == transport {
+		t.Fatal("the Client's own *http.Client was not restored")
+	}
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	clt, headers := recordHeaders(t)
+
+	clt.Call("GET", "/health", nil, nil)
+	if got := headers().Get("User-Agent"); got != userAgent || !strings.HasPrefix(got, "AppwriteGoSDK/"+SDKVersion+" (") {
+		t.Fatalf("sent User-Agent %q", got)
+	}
+
+	clt.AddHeader("User-Agent", "my-app/1.0")
+	clt.Call("GET", "/health", nil, nil)
+	if got := headers().Get("User-Agent"); got != "my-app/1.0" {
+		t.Fatalf("sent User-Agent %q", got)
+	}
+}
+
+func TestSetResponseFormat(t *testing.T) {
+	clt, headers := recordHeaders(t)
+
+	clt.Call("GET", "/health", nil, nil)
+	if got := headers().Get("X-Appwrite-Response-Format"); got != ResponseFormat {
+		t.Fatalf("sent response format %q", got)
+	}
+
+	clt.SetResponseFormat("1.4.0")
+	clt.Call("GET", "/health", nil, nil)
+	if got := headers().Get("X-Appwrite-Response-Format"); got != "1.4.0" {
+		t.Fatalf("sent response format %q", got)
+	}
+}
+
+func TestZeroValueClientSetters(t *testing.T) {
+	var clt Client
+	clt.SetProject("test")
+	clt.SetKey("key")
+	clt.AddHeader("X-Test", "a")
+
+	if clt.header("X-Appwrite-Project") != "test" || clt.header("X-Appwrite-Key") != "key" || clt.header("X-Test") != "a" {
+		t.Fatalf("got headers %v", clt.headersSnapshot())
+	}
+}
+
+func TestSetJWT(t *testing.T) {
+	clt, headers := recordHeaders(t)
+	clt.SetJWT("jwt-token")
+
+	clt.Call("GET", "/account", nil, nil)
+	if got := headers().Get("X-Appwrite-JWT"); got != "jwt-token" {
+		t.Fatalf("sent JWT %q", got)
+	}
+	if got := clt.Headers()["X-Appwrite-JWT"]; got != redacted {
+		t.Fatalf("Headers exposes the JWT as %q", got)
+	}
+}
+
+func TestSetSession(t *testing.T) {
+	clt, headers := recordHeaders(t)
+	clt.SetSession("session-secret")
+
+	clt.Call("GET", "/account", nil, nil)
+	if got := headers().Get("X-Appwrite-Session"); got != "session-secret" {
+		t.Fatalf("sent session %q", got)
+	}
+}
+
+func TestSessionCookiesReplayedWithJar(t *testing.T) {
+	var cookie string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		cookie = r.Header.Get("Cookie")
+		http.SetCookie(w, &http.Cookie{Name: "a_session_test", Value: "secret", Path: "/"})
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clt.SetHTTPClient(&http.Client{Jar: jar})
+
+	params := map[string]interface{}{"email": "a@b.c", "password": "password"}
+	if _, err := clt.Call("POST", "/account/sessions/email", nil, params); err != nil {
+		t.Fatal(err)
+	}
+	clt.Call("GET", "/account", nil, nil)
+	if cookie != "a_session_test=secret" {
+		t.Fatalf("sent cookie %q", cookie)
+	}
+}
+
+func TestUpdateQueryParameters(t *testing.T) {
+	tests := []struct {
+		params map[string]interface{}
+		want   string
+	}{
+		{map[string]interface{}{"queries": []string{"a", "b"}}, "queries%5B%5D=a&queries%5B%5D=b"},
+		{map[string]interface{}{"values": []interface{}{1, "z", true}}, "values%5B%5D=1&values%5B%5D=z&values%5B%5D=true"},
+		{map[string]interface{}{"queries": []string{}}, ""},
+		{map[string]interface{}{"filter": map[string]interface{}{"status": "active"}}, "filter%5Bstatus%5D=active"},
+		{map[string]interface{}{"search": "go", "cursor": nil}, "search=go"},
+		{map[string]interface{}{"since": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, "since=2024-01-02T03%3A04%3A05Z"},
+		{map[string]interface{}{"total": json.Number("12.50")}, "total=12.50"},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", "https://cloud.appwrite.io/v1/users", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updateQueryParameters(req, test.params)
+		if req.URL.RawQuery != test.want {
+			t.Errorf("encoded %v as %q, want %q", test.params, req.URL.RawQuery, test.want)
+		}
+	}
+}
+
+func TestCallEmptyResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"204", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}},
+		{"empty 200", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clt := newTestServer(t, test.handler)
+			result, err := clt.Call("DELETE", "/teams/t1", nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result) != 0 {
+				t.Fatalf("got %v", result)
+			}
+		})
+	}
+}
+
+func TestCallStream(t *testing.T) {
+	payload := make([]byte, 256*1024)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(payload)
+	})
+
+	body, headers, err := clt.CallStream("GET", "/storage/buckets/b/files/f/download", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, payload) {
+		t.Fatalf("read %d bytes that don't match the payload", len(data))
+	}
+	if got := headers.Get("Content-Type"); got != "application/octet-stream" {
+		t.Fatalf("got Content-Type %q", got)
+	}
+}
+
+func TestCallStreamError(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"message":"File not found","code":404,"type":"storage_file_not_found"}`)
+	})
+
+	body, _, err := clt.CallStream("GET", "/storage/buckets/b/files/f/download", nil, nil)
+	if body != nil || !IsType(err, ErrorTypeStorageFileNotFound) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestCallStreamNotModified(t *testing.T) {
+	var ifNoneMatch string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = r.Header.Get("If-None-Match")
+		w.Header().Set("ETag", `"v1"`)
+		if ifNoneMatch == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+	})
+
+	headers := map[string]interface{}{"If-None-Match": `"v1"`}
+	body, responseHeaders, err := clt.CallStream("GET", "/storage/buckets/b/files/f/preview", headers, nil)
+	if !errors.Is(err, ErrNotModified) {
+		t.Fatalf("got %v", err)
+	}
+	if body != nil {
+		t.Fatal("a body was returned for a 304")
+	}
+	if ifNoneMatch != `"v1"` || responseHeaders.Get("ETag") != `"v1"` {
+		t.Fatalf("sent If-None-Match %q, got ETag %q", ifNoneMatch, responseHeaders.Get("ETag"))
+	}
+
+	body, _, err = clt.CallStream("GET", "/storage/buckets/b/files/f/preview", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	if data, _ := io.ReadAll(body); string(data) != "png" {
+		t.Fatalf("got %q", data)
+	}
+}
+
+func TestInterceptorsFireInOrder(t *testing.T) {
+	clt, headers := recordHeaders(t)
+
+	var order []string
+	clt.AddRequestInterceptor(func(req *http.Request) {
+		order = append(order, "request 1")
+		req.Header.Set("X-Trace-Id", "abc")
+	})
+	clt.AddRequestInterceptor(func(req *http.Request) {
+		order = append(order, "request 2")
+		req.Header.Set("X-Trace-Id", req.Header.Get("X-Trace-Id")+"-def")
+	})
+	clt.AddResponseInterceptor(func(response *http.Response) {
+		order = append(order, fmt.Sprint("response 1 ", response.StatusCode))
+	})
+	clt.AddResponseInterceptor(func(*http.Response) {
+		order = append(order, "response 2")
+	})
+
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(order, ", "); got != "request 1, request 2, response 1 200, response 2" {
+		t.Fatalf("fired %s", got)
+	}
+	if got := headers().Get("X-Trace-Id"); got != "abc-def" {
+		t.Fatalf("sent trace id %q", got)
+	}
+}
+
+func TestSetEndpointRejectsBareHost(t *testing.T) {
+	clt := NewClient()
+	if err := clt.SetEndpoint("cloud.appwrite.io"); err == nil {
+		t.Fatal("expected an error for a bare host")
+	}
+	if got := clt.baseURL(); got != DefaultEndpoint {
+		t.Fatalf("endpoint changed to %q", got)
+	}
+
+	if err := clt.SetEndpoint("https://example.com/v1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := clt.baseURL(); got != "https://example.com/v1" {
+		t.Fatalf("got endpoint %q", got)
+	}
+}
+
+func TestJoinURL(t *testing.T) {
+	for _, endpoint := range []string{"https://example.com/v1", "https://example.com/v1/"} {
+		for _, path := range []string{"/health", "health"} {
+			if got := joinURL(endpoint, path); got != "https://example.com/v1/health" {
+				t.Errorf("joinURL(%q, %q) = %q", endpoint, path, got)
+			}
+		}
+	}
+	if got := joinURL("https://example.com/v1/", "/"); got != "https://example.com/v1" {
+		t.Errorf("got %q for an empty path", got)
+	}
+}
+
+func TestCallSendsSingleSlashPath(t *testing.T) {
+	clt, last := recordRequests(t, `{}`)
+	clt.SetEndpoint(clt.baseURL() + "/")
+
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := last().Path; got != "/v1/health" {
+		t.Fatalf("got path %q", got)
+	}
+}
+
+func TestCallWithoutProject(t *testing.T) {
+	transport := &countingTransport{}
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+	clt.SetHTTPClient(&http.Client{Transport: transport})
+	clt.SetProject("")
+
+	if _, err := clt.Call("GET", "/health", nil, nil); !errors.Is(err, ErrProjectNotSet) {
+		t.Fatalf("got %v", err)
+	}
+	if got := atomic.LoadInt32(&transport.requests); got != 0 {
+		t.Fatalf("sent %d requests", got)
+	}
+
+	clt.SetAnonymous(true)
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&transport.requests); got != 1 {
+		t.Fatalf("sent %d requests", got)
+	}
+}
+
+func TestCallNormalizesMethod(t *testing.T) {
+	clt, last := recordRequests(t, `{}`)
+
+	for method, want := range map[string]string{"patch": "PATCH", "Put": "PUT", "delete": "DELETE", "get": "GET"} {
+		if _, err := clt.Call(method, "/health", nil, nil); err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if got := last().Method; got != want {
+			t.Errorf("%s: sent method %q, want %q", method, got, want)
+		}
+	}
+
+	if _, err := clt.Call("FETCH", "/health", nil, nil); err == nil {
+		t.Fatal("expected an error for an unknown method")
+	}
+}
+
+func TestSetMode(t *testing.T) {
+	clt, headers := recordHeaders(t)
+
+	clt.SetModeTyped(ModeAdmin)
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := headers().Get("X-Appwrite-Mode"); got != "admin" {
+		t.Fatalf("sent mode %q", got)
+	}
+
+	if err := clt.SetMode("admn"); err == nil {
+		t.Fatal("expected an error for an unknown mode")
+	}
+	if got := clt.header("X-Appwrite-Mode"); got != "admin" {
+		t.Fatalf("mode changed to %q", got)
+	}
+	if err := clt.SetMode("default"); err != nil {
+		t.Fatal(err)
+	}
+	if got := clt.header("X-Appwrite-Mode"); got != "default" {
+		t.Fatalf("got mode %q", got)
+	}
+}
+
+func TestBuildURLMatchesCall(t *testing.T) {
+	var sent string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		sent = "http://" + r.Host + r.URL.RequestURI()
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	params := map[string]interface{}{
+		"queries": []string{`{"method":"limit","values":[5]}`},
+		"search":  "a b&c",
+	}
+	built, err := clt.BuildURL("get", "/databases/db/collections/c/documents", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clt.Call("GET", "/databases/db/collections/c/documents", nil, params); err != nil {
+		t.Fatal(err)
+	}
+	if built != sent {
+		t.Fatalf("built %q, sent %q", built, sent)
+	}
+	if !strings.Contains(built, "queries%5B%5D=") || !strings.Contains(built, "search=a+b%26c") {
+		t.Fatalf("built %q", built)
+	}
+}
+
+func TestSetMaxResponseBytes(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":%q}`, strings.Repeat("x", 4096))
+	})
+	clt.SetMaxResponseBytes(1024)
+
+	if _, err := clt.Call("GET", "/storage/files", nil, nil); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("got %v", err)
+	}
+
+	clt.SetMaxResponseBytes(8192)
+	if _, err := clt.Call("GET", "/storage/files", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	base := NewClient(WithProject("base"), WithKey("base-key"))
+	base.AddRequestInterceptor(func(req *http.Request) {})
+
+	clone := base.Clone()
+	clone.SetProject("tenant")
+	clone.SetKey("tenant-key")
+	clone.SetJWT("tenant-jwt")
+	clone.AddRequestInterceptor(func(req *http.Request) {})
+
+	if got := base.header("X-Appwrite-Project"); got != "base" {
+		t.Fatalf("base project changed to %q", got)
+	}
+	if got := base.header("X-Appwrite-Key"); got != "base-key" {
+		t.Fatalf("base key changed to %q", got)
+	}
+	if got := base.header("X-Appwrite-JWT"); got != "" {
+		t.Fatalf("base JWT set to %q", got)
+	}
+	if got := clone.header("X-Appwrite-Key"); got != "tenant-key" {
+		t.Fatalf("clone key is %q", got)
+	}
+	if got := len(base.config().requestInterceptors); got != 1 {
+		t.Fatalf("base has %d request interceptors", got)
+	}
+
+	base.SetKey("rotated")
+	if got := clone.header("X-Appwrite-Key"); got != "tenant-key" {
+		t.Fatalf("clone key changed to %q", got)
+	}
+	if base.httpClient() != clone.httpClient() {
+		t.Fatal("the clone does not share the *http.Client")
+	}
+}
+
+func TestHeadersReturnsMaskedCopy(t *testing.T) {
+	clt := NewClient(WithProject("test"), WithKey("secret"))
+	clt.SetJWT("token")
+
+	headers := clt.Headers()
+	if headers["X-Appwrite-Key"] != redacted || headers["X-Appwrite-JWT"] != redacted {
+		t.Fatalf("secrets were not masked: %v", headers)
+	}
+	if headers["X-Appwrite-Project"] != "test" {
+		t.Fatalf("got project %q", headers["X-Appwrite-Project"])
+	}
+
+	headers["X-Appwrite-Project"] = "changed"
+	delete(headers, "X-Appwrite-Key")
+	if got := clt.header("X-Appwrite-Project"); got != "test" {
+		t.Fatalf("project changed to %q", got)
+	}
+	if got := clt.header("X-Appwrite-Key"); got != "secret" {
+		t.Fatalf("key changed to %q", got)
+	}
+}
+
+func TestSetAPIVersion(t *testing.T) {
+	clt := NewClient()
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"https://example.com", "https://example.com/v1"},
+		{"https://example.com/", "https://example.com/v1"},
+		{"https://example.com/v1", "https://example.com/v1"},
+		{"https://example.com/v1/", "https://example.com/v1"},
+		{"https://example.com/appwrite/v2", "https://example.com/appwrite/v2"},
+	}
+	for _, test := range tests {
+		if err := clt.SetEndpoint(test.endpoint); err != nil {
+			t.Fatal(err)
+		}
+		if got := clt.baseURL(); got != test.want {
+			t.Errorf("endpoint %q used as %q, want %q", test.endpoint, got, test.want)
+		}
+	}
+
+	clt.SetEndpoint("https://example.com/appwrite")
+	clt.SetAPIVersion("/v2/")
+	got, err := clt.BuildURL("GET", "/health", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "https://example.com/appwrite/v2/health" {
+		t.Fatalf("built %q", got)
+	}
+}
+
+func TestCallAddsAPIVersion(t *testing.T) {
+	clt, last := recordRequests(t, `{}`)
+	clt.SetEndpoint(strings.TrimSuffix(clt.baseURL(), "/v1"))
+
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := last().Path; got != "/v1/health" {
+		t.Fatalf("got path %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+package appwrite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// compressionThreshold is the JSON body size above which bodies are gzipped
+// when compression is enabled
+const compressionThreshold = 1024
+
+// SetCompression sets whether JSON request bodies larger than 1KB are
+// gzipped before being sent. Compression is off by default
+func (clt *Client) SetCompression(status bool) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.compression = status
+	})
+}
+
+func gzipData(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// gzipReadCloser closes both the gzip reader and the response body it reads
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (r *gzipReadCloser) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
+}
+
+// decodeResponseBody replaces the body of a gzip encoded response with its
+// decompressed content. Responses already decompressed by the transport are
+// left untouched
+func decodeResponseBody(response *http.Response) error {
+	if response.Uncompressed || !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(response.Body)
+	if err != nil {
+		if err == io.EOF {
+			// Empty body, nothing to decompress
+			return nil
+		}
+		return err
+	}
+
+	response.Body = &gzipReadCloser{Reader: reader, body: response.Body}
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	return nil
+}
@@ -0,0 +1,108 @@
+package appwrite
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// recordEncodedBodies starts a test server decoding each JSON request body,
+// gunzipping it when sent with Content-Encoding: gzip
+func recordEncodedBodies(t *testing.T) (Client, *string, *map[string]interface{}) {
+	t.Helper()
+
+	var encoding string
+	var body map[string]interface{}
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+		reader := io.Reader(r.Body)
+		if encoding == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reader = gz
+		}
+		body = nil
+		if err := json.NewDecoder(reader).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+	return clt, &encoding, &body
+}
+
+func TestSetCompression(t *testing.T) {
+	clt, encoding, body := recordEncodedBodies(t)
+	clt.SetCompression(true)
+
+	content := strings.Repeat("lorem ipsum ", 200)
+	if _, err := clt.Call("POST", "/databases/db/collections/c/documents", nil, map[string]interface{}{"content": content}); err != nil {
+		t.Fatal(err)
+	}
+	if *encoding != "gzip" || (*body)["content"] != content {
+		t.Fatalf("sent %d chars with encoding %q", len((*body)["content"].(string)), *encoding)
+	}
+
+	// Small bodies aren't worth compressing
+	if _, err := clt.Call("POST", "/databases/db/collections/c/documents", nil, map[string]interface{}{"content": "short"}); err != nil {
+		t.Fatal(err)
+	}
+	if *encoding != "" || (*body)["content"] != "short" {
+		t.Fatalf("sent %v with encoding %q", *body, *encoding)
+	}
+}
+
+func TestCompressionOffByDefault(t *testing.T) {
+	clt, encoding, _ := recordEncodedBodies(t)
+
+	content := strings.Repeat("lorem ipsum ", 200)
+	if _, err := clt.Call("POST", "/databases/db/collections/c/documents", nil, map[string]interface{}{"content": content}); err != nil {
+		t.Fatal(err)
+	}
+	if *encoding != "" {
+		t.Fatalf("sent encoding %q", *encoding)
+	}
+}
+
+func TestGzipResponses(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"status":"pass"}`))
+		gz.Close()
+	})
+
+	result, err := clt.Call("GET", "/health", nil, nil)
+	if err != nil || result["status"] != "pass" {
+		t.Fatalf("got %v, %v", result, err)
+	}
+
+	// Asking for gzip explicitly leaves decompression to the Client rather
+	// than the transport
+	clt.AddHeader("Accept-Encoding", "gzip")
+	result, err = clt.Call("GET", "/health", nil, nil)
+	if err != nil || result["status"] != "pass" {
+		t.Fatalf("got %v, %v", result, err)
+	}
+}
+
+func TestDecodeResponseBodyLeavesUncompressedResponses(t *testing.T) {
+	response := &http.Response{
+		Header:       http.Header{},
+		Body:         io.NopCloser(strings.NewReader(`{"status":"pass"}`)),
+		Uncompressed: true,
+	}
+	if err := decodeResponseBody(response); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(response.Body)
+	if string(data) != `{"status":"pass"}` {
+		t.Fatalf("got %q", data)
+	}
+}
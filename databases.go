@@ -0,0 +1,206 @@
+package appwrite
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Databases service
+type Databases struct {
+	client Client
+}
+
+func NewDatabases(clt Client) Databases {
+	service := Databases{
+		client: clt,
+	}
+
+	return service
+}
+
+// ListDocuments get a list of all the user's documents in a given
+// collection. You can use the query params to filter your results.
+func (srv *Databases) ListDocuments(DatabaseId string, CollectionId string, Queries []string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{databaseId}", url.PathEscape(DatabaseId), "{collectionId}", url.PathEscape(CollectionId))
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents")
+
+	params := map[string]interface{}{
+		"queries": Queries,
+	}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// ListDocumentsTyped get a page of the user's documents in a given
+// collection, decoded into T. You can use the query params to filter your
+// results, and the LastID of the page with Query.CursorAfter to get the next
+// one.
+func ListDocumentsTyped[T any](srv *Databases, DatabaseId string, CollectionId string, Queries []string) (Page[T], error) {
+	resp, err := srv.ListDocuments(DatabaseId, CollectionId, Queries)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	return parsePage[T](resp, "documents")
+}
+
+// CreateDocument create a new Document. Before using this route, you should
+// create a new collection resource using either a server integration API or
+// directly from your database console.
+func (srv *Databases) CreateDocument(DatabaseId string, CollectionId string, DocumentId string, Data interface{}, Permissions []string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{databaseId}", url.PathEscape(DatabaseId), "{collectionId}", url.PathEscape(CollectionId))
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents")
+
+	params := map[string]interface{}{
+		"documentId":  DocumentId,
+		"data":        Data,
+		"permissions": Permissions,
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// GetDocument get a document by its unique ID. This endpoint response
+// returns a JSON object with the document data.
+func (srv *Databases) GetDocument(DatabaseId string, CollectionId string, DocumentId string, Queries []string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{databaseId}", url.PathEscape(DatabaseId), "{collectionId}", url.PathEscape(CollectionId), "{documentId}", url.PathEscape(DocumentId))
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
+
+	params := map[string]interface{}{
+		"queries": Queries,
+	}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// UpdateDocument update a document by its unique ID. Using the patch method
+// you can pass only specific fields that will get updated.
+func (srv *Databases) UpdateDocument(DatabaseId string, CollectionId string, DocumentId string, Data interface{}, Permissions []string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{databaseId}", url.PathEscape(DatabaseId), "{collectionId}", url.PathEscape(CollectionId), "{documentId}", url.PathEscape(DocumentId))
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
+
+	params := map[string]interface{}{
+		"data":        Data,
+		"permissions": Permissions,
+	}
+
+	return srv.client.Call("PATCH", path, nil, params)
+}
+
+// UpdateDocumentIfUnmodifiedSince update a document by its unique ID, unless
+// it was modified after the given time. A document changed in the meantime
+// makes the server answer with a 409 conflict, returned as an
+// AppwriteException.
+func (srv *Databases) UpdateDocumentIfUnmodifiedSince(DatabaseId string, CollectionId string, DocumentId string, Data interface{}, Permissions []string, Since time.Time) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{databaseId}", url.PathEscape(DatabaseId), "{collectionId}", url.PathEscape(CollectionId), "{documentId}", url.PathEscape(DocumentId))
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
+
+	params := map[string]interface{}{
+		"data":        Data,
+		"permissions": Permissions,
+	}
+
+	response, err := srv.client.CallWithOptions("PATCH", path, params, CallOptions{Timestamp: Since})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+// PatchDocument update only the given attributes of a document by its
+// unique ID. Attributes missing from Changes, and the document permissions,
+// are left as they are on the server, so concurrent changes to other
+// attributes are kept, while an attribute set to nil is cleared. When Since
+// is not zero the update is refused with a 409 conflict if the document was
+// modified after that time.
+func (srv *Databases) PatchDocument(DatabaseId string, CollectionId string, DocumentId string, Changes map[string]interface{}, Since time.Time) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{databaseId}", url.PathEscape(DatabaseId), "{collectionId}", url.PathEscape(CollectionId), "{documentId}", url.PathEscape(DocumentId))
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
+
+	params := map[string]interface{}{
+		"data": Changes,
+	}
+
+	response, err := srv.client.CallWithOptions("PATCH", path, params, CallOptions{Timestamp: Since})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+// DeleteDocument delete a document by its unique ID.
+func (srv *Databases) DeleteDocument(DatabaseId string, CollectionId string, DocumentId string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{databaseId}", url.PathEscape(DatabaseId), "{collectionId}", url.PathEscape(CollectionId), "{documentId}", url.PathEscape(DocumentId))
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents/{documentId}")
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("DELETE", path, nil, params)
+}
+
+// DocPayload is a document to create with CreateDocumentsBatch
+type DocPayload struct {
+	DocumentId  string
+	Data        interface{}
+	Permissions []string
+}
+
+// BatchResult is the outcome of creating a single document of a batch
+type BatchResult struct {
+	Document map[string]interface{}
+	Err      error
+}
+
+// CreateDocumentsBatch create many documents in a collection, sending up to
+// Concurrency requests at a time. Results are aligned with Docs, each
+// holding either the created document or the error it failed with. Once ctx
+// is cancelled no new document is sent, the remaining results hold the
+// context error, and that error is returned.
+func (srv *Databases) CreateDocumentsBatch(ctx context.Context, DatabaseId string, CollectionId string, Docs []DocPayload, Concurrency int) ([]BatchResult, error) {
+	r := strings.NewReplacer("{databaseId}", url.PathEscape(DatabaseId), "{collectionId}", url.PathEscape(CollectionId))
+	path := r.Replace("/databases/{databaseId}/collections/{collectionId}/documents")
+
+	if Concurrency < 1 {
+		Concurrency = 1
+	}
+
+	results := make([]BatchResult, len(Docs))
+	slots := make(chan struct{}, Concurrency)
+	var wg sync.WaitGroup
+
+	for i, doc := range Docs {
+		select {
+		case <-ctx.Done():
+		case slots <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			for j := i; j < len(Docs); j++ {
+				results[j].Err = ctx.Err()
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, doc DocPayload) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			params := map[string]interface{}{
+				"documentId":  doc.DocumentId,
+				"data":        doc.Data,
+				"permissions": doc.Permissions,
+			}
+
+			results[i].Document, results[i].Err = srv.client.CallWithContext(ctx, "POST", path, nil, params)
+		}(i, doc)
+	}
+
+	wg.Wait()
+
+	return results, ctx.Err()
+}
@@ -0,0 +1,251 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDatabasesDocumentPaths(t *testing.T) {
+	clt, last := recordRequests(t, `{}`)
+	srv := NewDatabases(clt)
+	data := map[string]interface{}{"title": "Hello"}
+	permissions := []string{`read("any")`}
+
+	tests := []struct {
+		name   string
+		call   func() (map[string]interface{}, error)
+		method string
+		path   string
+	}{
+		{"ListDocuments", func() (map[string]interface{}, error) {
+			return srv.ListDocuments("db", "c/1", nil)
+		}, "GET", "/v1/databases/db/collections/c%2F1/documents"},
+		{"CreateDocument", func() (map[string]interface{}, error) {
+			return srv.CreateDocument("db", "c/1", "unique()", data, permissions)
+		}, "POST", "/v1/databases/db/collections/c%2F1/documents"},
+		{"GetDocument", func() (map[string]interface{}, error) {
+			return srv.GetDocument("db", "c/1", "a b?", nil)
+		}, "GET", "/v1/databases/db/collections/c%2F1/documents/a%20b%3F"},
+		{"UpdateDocument", func() (map[string]interface{}, error) {
+			return srv.UpdateDocument("db", "c/1", "a b?", data, permissions)
+		}, "PATCH", "/v1/databases/db/collections/c%2F1/documents/a%20b%3F"},
+		{"DeleteDocument", func() (map[string]interface{}, error) {
+			return srv.DeleteDocument("db#1", "c/1", "a b?")
+		}, "DELETE", "/v1/databases/db%231/collections/c%2F1/documents/a%20b%3F"},
+	}
+	for _, test := range tests {
+		if _, err := test.call(); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if req := last(); req.Method != test.method || req.Path != test.path {
+			t.Errorf("%s: sent %s %s, want %s %s", test.name, req.Method, req.Path, test.method, test.path)
+		}
+	}
+}
+
+func TestDatabasesCreateDocumentBody(t *testing.T) {
+	clt, last := recordRequests(t, `{}`)
+	srv := NewDatabases(clt)
+
+	if _, err := srv.CreateDocument("db", "c", "d1", map[string]interface{}{"title": "Hello"}, []string{`read("any")`}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"documentId":  "d1",
+		"data":        map[string]interface{}{"title": "Hello"},
+		"permissions": []interface{}{`read("any")`},
+	}
+	if body := last().Body; !reflect.DeepEqual(body, want) {
+		t.Fatalf("sent %v", body)
+	}
+}
+
+func TestDatabasesListDocumentsQueries(t *testing.T) {
+	clt, last := recordRequests(t, `{"total":0,"documents":[]}`)
+	srv := NewDatabases(clt)
+
+	queries := []string{Query{}.Equal("status", "active"), Query{}.Limit(10)}
+	if _, err := srv.ListDocuments("db", "c", queries); err != nil {
+		t.Fatal(err)
+	}
+	if got := last().Query["queries[]"]; !reflect.DeepEqual(got, queries) {
+		t.Fatalf("sent queries %q", got)
+	}
+}
+
+func TestUpdateDocumentIfUnmodifiedSinceConflict(t *testing.T) {
+	var timestamp string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		timestamp = r.Header.Get("X-Appwrite-Timestamp")
+		writeJSON(w, http.StatusConflict, `{"message":"Remote document is newer than local.","code":409,"type":"document_update_conflict"}`)
+	})
+	srv := NewDatabases(clt)
+
+	since := time.Date(2024, 5, 1, 12, 30, 0, 250e6, time.FixedZone("CEST", 2*60*60))
+	_, err := srv.UpdateDocumentIfUnmodifiedSince("db", "c", "d", map[string]interface{}{"a": 1}, nil, since)
+	if timestamp != "2024-05-01T10:30:00.250Z" {
+		t.Fatalf("sent timestamp %q", timestamp)
+	}
+
+	var exception *AppwriteException
+	if !errors.As(err, &exception) {
+		t.Fatalf("got %v", err)
+	}
+	if exception.Code != http.StatusConflict || exception.Type != "document_update_conflict" {
+		t.Fatalf("got %+v", exception)
+	}
+}
+
+func TestCreateDocumentsBatch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+
+		var body struct {
+			DocumentId string `json:"documentId"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		id, _ := strconv.Atoi(body.DocumentId)
+		// Answer the first documents last so results complete out of order
+		time.Sleep(time.Duration(10-id) * time.Millisecond)
+		if id%4 == 3 {
+			writeJSON(w, http.StatusBadRequest, `{"message":"Invalid document structure","code":400,"type":"document_invalid_structure"}`)
+			return
+		}
+		writeJSON(w, http.StatusCreated, fmt.Sprintf(`{"$id":%q}`, body.DocumentId))
+	})
+	srv := NewDatabases(clt)
+
+	docs := make([]DocPayload, 10)
+	for i := range docs {
+		docs[i] = DocPayload{DocumentId: strconv.Itoa(i), Data: map[string]interface{}{"n": i}}
+	}
+
+	results, err := srv.CreateDocumentsBatch(context.Background(), "db", "c", docs, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(docs) {
+		t.Fatalf("got %d results", len(results))
+	}
+	for i, result := range results {
+		if i%4 == 3 {
+			var exception *AppwriteException
+			if !errors.As(result.Err, &exception) || exception.Code != http.StatusBadRequest {
+				t.Errorf("document %d: got error %v", i, result.Err)
+			}
+			continue
+		}
+		if result.Err != nil || result.Document["$id"] != strconv.Itoa(i) {
+			t.Errorf("document %d: got %v, %v", i, result.Document, result.Err)
+		}
+	}
+	if max := atomic.LoadInt32(&maxInFlight); max > 3 {
+		t.Fatalf("sent %d requests at a time", max)
+	}
+}
+
+func TestCreateDocumentsBatchCancelled(t *testing.T) {
+	var requests int32
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+	srv := NewDatabases(clt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := srv.CreateDocumentsBatch(ctx, "db", "c", make([]DocPayload, 5), 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v", err)
+	}
+	for i, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("document %d: got error %v", i, result.Err)
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 0 {
+		t.Fatalf("sent %d requests after cancellation", got)
+	}
+}
+
+func TestListDocumentsTyped(t *testing.T) {
+	clt, last := recordRequests(t, testDocumentsBody)
+	srv := NewDatabases(clt)
+
+	page, err := ListDocumentsTyped[testDocument](&srv, "db", "c", []string{Query{}.Limit(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 2 || len(page.Documents) != 2 || page.Documents[1] != (testDocument{ID: "b", Title: "Second", Views: 2}) {
+		t.Fatalf("got %+v", page)
+	}
+	if got := page.LastID(); got != "b" {
+		t.Fatalf("got last id %q", got)
+	}
+
+	next := []string{Query{}.Limit(2), Query{}.CursorAfter(page.LastID())}
+	if _, err := ListDocumentsTyped[testDocument](&srv, "db", "c", next); err != nil {
+		t.Fatal(err)
+	}
+	if got := last().Query["queries[]"]; !reflect.DeepEqual(got, next) {
+		t.Fatalf("sent queries %q", got)
+	}
+}
+
+func TestListDocumentsTypedEmptyPage(t *testing.T) {
+	clt, _ := recordRequests(t, `{"total":0,"documents":[]}`)
+	srv := NewDatabases(clt)
+
+	page, err := ListDocumentsTyped[testDocument](&srv, "db", "c", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 0 || len(page.Documents) != 0 || page.LastID() != "" {
+		t.Fatalf("got %+v", page)
+	}
+}
+
+func TestPatchDocument(t *testing.T) {
+	var body, timestamp string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body, timestamp = string(data), r.Header.Get("X-Appwrite-Timestamp")
+		if r.Method != "PATCH" || r.URL.Path != "/v1/databases/db/collections/c/documents/d" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, `{"$id":"d"}`)
+	})
+	srv := NewDatabases(clt)
+
+	if _, err := srv.PatchDocument("db", "c", "d", map[string]interface{}{"title": "New"}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if body != `{"data":{"title":"New"}}` || timestamp != "" {
+		t.Fatalf("sent body %q with timestamp %q", body, timestamp)
+	}
+
+	since := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	if _, err := srv.PatchDocument("db", "c", "d", map[string]interface{}{"summary": nil}, since); err != nil {
+		t.Fatal(err)
+	}
+	if body != `{"data":{"summary":null}}` || timestamp != "2024-05-01T10:30:00.000Z" {
+		t.Fatalf("sent body %q with timestamp %q", body, timestamp)
+	}
+}
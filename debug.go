@@ -0,0 +1,172 @@
+package appwrite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// sensitiveHeaders lists the headers holding credentials, on top of those
+// matched by sensitiveParams
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Appwrite-JWT", "X-Appwrite-Session"}
+
+// DebugEvent describes a request sent by the Client and the response it got.
+// Credentials in headers and secrets in bodies are redacted
+type DebugEvent struct {
+	Method       string
+	URL          string
+	Headers      http.Header
+	RequestBody  string
+	StatusCode   int
+	ResponseBody string
+}
+
+// SetDebugLogger sets a function receiving a DebugEvent for every response
+// the Client receives. Debug events are off by default
+func (clt *Client) SetDebugLogger(logger func(DebugEvent)) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.debugLogger = logger
+	})
+}
+
+// debugBodyPeekSize is the largest request or response body reported to the
+// debug logger
+const debugBodyPeekSize = 64 * 1024
+
+// logDebug reports a request and its response to the debug logger. The
+// response body is left readable by the caller
+func (clt *Client) logDebug(req *http.Request, response *http.Response) {
+	debugLogger := clt.config().debugLogger
+	if debugLogger == nil {
+		return
+	}
+
+	debugLogger(DebugEvent{
+		Method:       req.Method,
+		URL:          redactURL(req.URL),
+		Headers:      redactHeaders(req.Header),
+		RequestBody:  debugRequestBody(req),
+		StatusCode:   response.StatusCode,
+		ResponseBody: debugResponseBody(response),
+	})
+}
+
+// debugRequestBody returns the loggable version of the body of req. Like
+// response bodies, only JSON bodies of up to debugBodyPeekSize bytes are
+// read, from a copy obtained with GetBody
+func debugRequestBody(req *http.Request) string {
+	if req.GetBody == nil || req.ContentLength == 0 {
+		return ""
+	}
+	if omitted, ok := omittedBody(req.Header); ok {
+		return omitted
+	}
+	if req.ContentLength > debugBodyPeekSize {
+		return largeBodyOmitted
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "[body omitted]"
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, debugBodyPeekSize+1))
+	if err != nil {
+		return "[body omitted]"
+	}
+	if len(data) > debugBodyPeekSize {
+		return largeBodyOmitted
+	}
+	return redactBody(req.Header, data)
+}
+
+// debugResponseBody returns the loggable version of the body of response.
+// Only JSON bodies are read, up to debugBodyPeekSize bytes, so that streamed
+// and binary bodies are never buffered
+func debugResponseBody(response *http.Response) string {
+	if response.ContentLength == 0 {
+		return ""
+	}
+	if omitted, ok := omittedBody(response.Header); ok {
+		return omitted
+	}
+
+	peeked, err := peekBody(response, debugBodyPeekSize+1)
+	if err != nil {
+		return "[body omitted]"
+	}
+	if len(peeked) > debugBodyPeekSize {
+		return largeBodyOmitted
+	}
+	return redactBody(response.Header, peeked)
+}
+
+// largeBodyOmitted replaces bodies larger than debugBodyPeekSize
+var largeBodyOmitted = fmt.Sprintf("[body larger than %d bytes omitted]", debugBodyPeekSize)
+
+// omittedBody returns what replaces a body with the given headers that
+// can't be inspected for secrets, being encoded or not JSON, and false for a
+// JSON body
+func omittedBody(headers http.Header) (string, bool) {
+	if headers.Get("Content-Encoding") != "" || !isJSONContentType(headers.Get("Content-Type")) {
+		return "[" + headers.Get("Content-Type") + " body omitted]", true
+	}
+	return "", false
+}
+
+// peekBody reads up to n bytes of the body of response, which is left
+// readable from its start
+func peekBody(response *http.Response, n int64) ([]byte, error) {
+	peeked, err := io.ReadAll(io.LimitReader(response.Body, n))
+	response.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peeked), response.Body), response.Body}
+	return peeked, err
+}
+
+// redactHeaders returns a copy of headers with credentials redacted
+func redactHeaders(headers http.Header) http.Header {
+	copied := headers.Clone()
+	for name := range copied {
+		if isSensitive(name) || isSensitiveHeader(name) {
+			copied.Set(name, redacted)
+		}
+	}
+	return copied
+}
+
+func isSensitiveHeader(name string) bool {
+	for _, sensitive := range sensitiveHeaders {
+		if strings.EqualFold(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody returns a loggable version of a body. JSON objects have their
+// sensitive fields redacted, while other content is left out as it can't be
+// inspected for secrets
+func redactBody(headers http.Header, data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	if omitted, ok := omittedBody(headers); ok {
+		return omitted
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return "[body omitted]"
+	}
+	redactedData, err := json.Marshal(redactParams(object))
+	if err != nil {
+		return "[body omitted]"
+	}
+	return string(redactedData)
+}
@@ -0,0 +1,154 @@
+package appwrite
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDebugLoggerRedactsSecrets(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, `{"secret":"s3cr3t","name":"a"}`)
+	})
+	clt.SetKey("api-key")
+
+	var event DebugEvent
+	clt.SetDebugLogger(func(e DebugEvent) { event = e })
+
+	params := map[string]interface{}{"password": "hunter2", "email": "a@b.c"}
+	result, err := clt.Call("POST", "/account", nil, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["secret"] != "s3cr3t" {
+		t.Fatalf("the body was not left readable: %v", result)
+	}
+
+	if event.Method != "POST" || event.StatusCode != http.StatusCreated {
+		t.Fatalf("got %s %d", event.Method, event.StatusCode)
+	}
+	if got := event.Headers.Get("X-Appwrite-Key"); got != redacted {
+		t.Fatalf("key header logged as %q", got)
+	}
+	for _, secret := range []string{"api-key", "hunter2", "s3cr3t"} {
+		if strings.Contains(event.RequestBody+event.ResponseBody, secret) {
+			t.Fatalf("%q logged in %q and %q", secret, event.RequestBody, event.ResponseBody)
+		}
+	}
+	if !strings.Contains(event.RequestBody, "a@b.c") {
+		t.Fatalf("request body %q", event.RequestBody)
+	}
+}
+
+func TestDebugLoggerDoesNotBufferStreams(t *testing.T) {
+	content := strings.Repeat("x", 1<<20)
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		io.WriteString(w, content)
+	})
+
+	var event DebugEvent
+	clt.SetDebugLogger(func(e DebugEvent) { event = e })
+
+	body, _, err := clt.CallStream("GET", "/storage/buckets/b/files/f/download", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	if strings.Contains(event.ResponseBody, "x") {
+		t.Fatalf("response body logged: %.20q", event.ResponseBody)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil || len(data) != len(content) {
+		t.Fatalf("read %d bytes: %v", len(data), err)
+	}
+}
+
+func TestDebugLoggerOmitsLargeBodies(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"data":"`+strings.Repeat("x", debugBodyPeekSize)+`"}`)
+	})
+
+	var event DebugEvent
+	clt.SetDebugLogger(func(e DebugEvent) { event = e })
+
+	result, err := clt.Call("GET", "/health", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := result["data"].(string); len(data) != debugBodyPeekSize {
+		t.Fatalf("read %d bytes of data", len(data))
+	}
+	if !strings.Contains(event.ResponseBody, "omitted") {
+		t.Fatalf("response body logged: %.20q", event.ResponseBody)
+	}
+}
+
+func TestDebugLoggerRedactsQuery(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	var event DebugEvent
+	clt.SetDebugLogger(func(e DebugEvent) { event = e })
+
+	if _, err := clt.Call("GET", "/users", nil, map[string]interface{}{"search": "ada", "secret": "s3cr3t"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(event.URL, "s3cr3t") || !strings.HasSuffix(event.URL, "/v1/users?search=ada&secret=[REDACTED]") {
+		t.Fatalf("logged URL %q", event.URL)
+	}
+}
+
+// countingReader counts the bytes read from it
+type countingReader struct {
+	reader io.Reader
+	read   int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += n
+	return n, err
+}
+
+func TestDebugRequestBodyReadsAtMostPeekSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentType   string
+		contentLength int64
+		maxRead       int
+	}{
+		{"binary", "application/octet-stream", -1, 0},
+		{"large JSON", "application/json", 5 << 20, 0},
+		{"JSON of unknown length", "application/json", -1, debugBodyPeekSize + 1},
+	}
+	for _, test := range tests {
+		var getBody int
+		var body *countingReader
+		req, err := http.NewRequest("POST", "https://example.com/v1/storage/buckets/b/files", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", test.contentType)
+		req.ContentLength = test.contentLength
+		req.GetBody = func() (io.ReadCloser, error) {
+			getBody++
+			body = &countingReader{reader: strings.NewReader(`{"data":"` + strings.Repeat("x", 5<<20) + `"}`)}
+			return io.NopCloser(body), nil
+		}
+
+		logged := debugRequestBody(req)
+		if !strings.Contains(logged, "omitted") {
+			t.Errorf("%s: logged %.20q", test.name, logged)
+		}
+		if test.maxRead == 0 && getBody != 0 {
+			t.Errorf("%s: the body was read", test.name)
+		}
+		if body != nil && body.read > test.maxRead {
+			t.Errorf("%s: read %d bytes", test.name, body.read)
+		}
+	}
+}
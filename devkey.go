@@ -0,0 +1,37 @@
+package appwrite
+
+import (
+	"log"
+)
+
+// DevKeyHeader is the header carrying a development key
+const DevKeyHeader = "X-Appwrite-Dev-Key"
+
+// devKeyWarning is logged once by a Client and its copies
+const devKeyWarning = "warning: requests are sent with a dev key, which lifts rate limits and must never be used in production"
+
+// SetDevKey sets a development key, sent along with the project ID in the
+// X-Appwrite-Dev-Key header to lift rate limits and other restrictions
+// while developing locally. Dev keys are meant for development only and must
+// never be used in production: the first request sent with one logs a
+// warning through the logger set with SetLogger, or the standard logger when
+// none is set
+func (clt *Client) SetDevKey(key string) {
+	clt.setHeader(DevKeyHeader, key)
+}
+
+// warnDevKey logs the dev key warning, unless it was logged already
+func (clt *Client) warnDevKey() {
+	mu := clt.lock()
+	mu.RLock()
+	once := clt.devKeyWarning
+	mu.RUnlock()
+
+	once.Do(func() {
+		if logger := clt.config().logger; logger != nil {
+			logger(devKeyWarning)
+			return
+		}
+		log.Print(devKeyWarning)
+	})
+}
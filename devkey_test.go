@@ -0,0 +1,65 @@
+package appwrite
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSetDevKey(t *testing.T) {
+	var devKey, project string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		devKey, project = r.Header.Get(DevKeyHeader), r.Header.Get("X-Appwrite-Project")
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	var mu sync.Mutex
+	var warnings []string
+	clt.SetLogger(func(msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasPrefix(msg, "warning") {
+			warnings = append(warnings, msg)
+		}
+	})
+
+	// The service is created first, and must warn once along with clt
+	srv := NewHealth(clt)
+	clt.SetDevKey("dev")
+	for i := 0; i < 2; i++ {
+		if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := srv.Get(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if devKey != "dev" || project != "test" {
+		t.Fatalf("sent dev key %q and project %q", devKey, project)
+	}
+	if len(warnings) != 1 || warnings[0] != devKeyWarning {
+		t.Fatalf("logged %q", warnings)
+	}
+}
+
+func TestSetDevKeyWarnsWithoutLogger(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	var output bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&output)
+
+	clt.SetDevKey("dev")
+	clt.Call("GET", "/health", nil, nil)
+	clt.Call("GET", "/health", nil, nil)
+
+	if got := strings.Count(output.String(), devKeyWarning); got != 1 {
+		t.Fatalf("logged the warning %d times", got)
+	}
+}
@@ -0,0 +1,115 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// AppwriteException is the error returned by the Client when the server
+// responds with an error status code
+type AppwriteException struct {
+	Message  string
+	Code     int
+	Type     string
+	Response string
+
+	// Locale is the X-Appwrite-Locale the failed request was sent with,
+	// which the server may have translated Message to
+	Locale string
+}
+
+func (e *AppwriteException) Error() string {
+	return e.Message
+}
+
+// Error types reported by Appwrite in the type field of error responses
+const (
+	ErrorTypeGeneralUnknown           = "general_unknown"
+	ErrorTypeGeneralRateLimit         = "general_rate_limit_exceeded"
+	ErrorTypeGeneralUnauthorizedScope = "general_unauthorized_scope"
+	ErrorTypeGeneralArgumentInvalid   = "general_argument_invalid"
+	ErrorTypeGeneralFormatUnsupported = "general_response_format_unsupported"
+	ErrorTypeProjectNotFound          = "project_not_found"
+	ErrorTypeUserNotFound             = "user_not_found"
+	ErrorTypeUserAlreadyExists        = "user_already_exists"
+	ErrorTypeUserInvalidCredentials   = "user_invalid_credentials"
+	ErrorTypeUserUnauthorized         = "user_unauthorized"
+	ErrorTypeUserSessionNotFound      = "user_session_not_found"
+	ErrorTypeUserJWTInvalid           = "user_jwt_invalid"
+	ErrorTypeTeamNotFound             = "team_not_found"
+	ErrorTypeMembershipNotFound       = "membership_not_found"
+	ErrorTypeDatabaseNotFound         = "database_not_found"
+	ErrorTypeCollectionNotFound       = "collection_not_found"
+	ErrorTypeDocumentNotFound         = "document_not_found"
+	ErrorTypeDocumentAlreadyExists    = "document_already_exists"
+	ErrorTypeDocumentInvalidStructure = "document_invalid_structure"
+	ErrorTypeStorageFileNotFound      = "storage_file_not_found"
+	ErrorTypeStorageBucketNotFound    = "storage_bucket_not_found"
+	ErrorTypeFunctionNotFound         = "function_not_found"
+	ErrorTypeExecutionNotFound        = "execution_not_found"
+)
+
+// IsType reports whether err is, or wraps, an AppwriteException of the given
+// error type
+func IsType(err error, errorType string) bool {
+	var exception *AppwriteException
+	return errors.As(err, &exception) && exception.Type == errorType
+}
+
+// newAppwriteException builds an AppwriteException from an error response,
+// using the structured JSON error body when the server returned one
+func newAppwriteException(response *http.Response, body []byte) *AppwriteException {
+	exception := &AppwriteException{
+		Message:  strings.TrimSpace(string(body)),
+		Code:     response.StatusCode,
+		Response: string(body),
+	}
+	if response.Request != nil {
+		exception.Locale = response.Request.Header.Get("X-Appwrite-Locale")
+	}
+
+	contentType := response.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); !isJSONContentType(contentType) && mediaType != "text/plain" {
+		// Error pages from proxies, such as an HTML bad gateway page
+		exception.Message = unexpectedContentTypeMessage(contentType, body)
+	}
+
+	var errorBody struct {
+		Message string  `json:"message"`
+		Code    float64 `json:"code"`
+		Type    string  `json:"type"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err == nil {
+		if errorBody.Message != "" {
+			exception.Message = errorBody.Message
+		}
+		if errorBody.Code != 0 {
+			exception.Code = int(errorBody.Code)
+		}
+		exception.Type = errorBody.Type
+	}
+
+	if exception.Message == "" {
+		exception.Message = http.StatusText(response.StatusCode)
+	}
+
+	return exception
+}
+
+// bodySnippetSize is how much of an unexpected response body is quoted in
+// error messages
+const bodySnippetSize = 256
+
+// unexpectedContentTypeMessage describes a response body that is not JSON,
+// quoting its beginning
+func unexpectedContentTypeMessage(contentType string, body []byte) string {
+	snippet := strings.TrimSpace(string(body))
+	if len(snippet) > bodySnippetSize {
+		snippet = snippet[:bodySnippetSize] + "..."
+	}
+	return fmt.Sprintf("unexpected content-type %s: %s", contentType, snippet)
+}
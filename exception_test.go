@@ -0,0 +1,100 @@
+package appwrite
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCallReturnsAppwriteException(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"message":"Document not found","code":404,"type":"document_not_found"}`)
+	})
+
+	_, err := clt.Call("GET", "/databases/db/collections/c/documents/d", nil, nil)
+	var exception *AppwriteException
+	if !errors.As(err, &exception) {
+		t.Fatalf("got %v", err)
+	}
+	if exception.Message != "Document not found" || exception.Code != http.StatusNotFound || exception.Type != "document_not_found" {
+		t.Fatalf("got %+v", exception)
+	}
+	if exception.Response == "" {
+		t.Fatal("the response body is missing")
+	}
+}
+
+func TestCallReturnsAppwriteExceptionForPlainText(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintln(w, "internal error")
+	})
+
+	_, err := clt.Call("GET", "/health", nil, nil)
+	var exception *AppwriteException
+	if !errors.As(err, &exception) {
+		t.Fatalf("got %v", err)
+	}
+	if exception.Message != "internal error" || exception.Code != http.StatusInternalServerError || exception.Type != "" {
+		t.Fatalf("got %+v", exception)
+	}
+}
+
+func TestCallReportsHTMLErrorPage(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "<html>bad gateway</html>")
+	})
+
+	_, err := clt.Call("GET", "/health", nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if got, want := err.Error(), "unexpected content-type text/html: <html>bad gateway</html>"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestIsType(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusConflict, `{"message":"A user with the same id already exists.","code":409,"type":"user_already_exists"}`)
+	})
+
+	_, err := clt.Call("POST", "/users", nil, map[string]interface{}{"userId": "u1"})
+	if !IsType(err, ErrorTypeUserAlreadyExists) {
+		t.Fatalf("%v is not of type %s", err, ErrorTypeUserAlreadyExists)
+	}
+	if !IsType(fmt.Errorf("create user: %w", err), ErrorTypeUserAlreadyExists) {
+		t.Fatal("the type of a wrapped exception was not matched")
+	}
+	if IsType(err, ErrorTypeUserNotFound) {
+		t.Fatalf("%v matched type %s", err, ErrorTypeUserNotFound)
+	}
+	if IsType(errors.New("user_already_exists"), ErrorTypeUserAlreadyExists) || IsType(nil, "") {
+		t.Fatal("matched an error that is not an AppwriteException")
+	}
+}
+
+func TestAppwriteExceptionLocale(t *testing.T) {
+	var locale string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		locale = r.Header.Get("X-Appwrite-Locale")
+		writeJSON(w, http.StatusBadRequest, `{"message":"Ungültiger Parameter","code":400,"type":"general_argument_invalid"}`)
+	})
+	clt.SetLocale("de")
+
+	_, err := clt.Call("POST", "/teams", nil, map[string]interface{}{"name": ""})
+	if locale != "de" {
+		t.Fatalf("sent locale %q", locale)
+	}
+	var exception *AppwriteException
+	if !errors.As(err, &exception) {
+		t.Fatalf("got %v", err)
+	}
+	if exception.Locale != "de" || exception.Message != "Ungültiger Parameter" {
+		t.Fatalf("got %+v", exception)
+	}
+}
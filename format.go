@@ -0,0 +1,39 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// formatErrorPeekSize is how much of an error response is read to find out
+// whether the server rejected the response format
+const formatErrorPeekSize = 64 * 1024
+
+// SetResponseFormatFallback sets an older response format to retry with,
+// once, when the server rejects the response format of a request with a
+// general_response_format_unsupported error. This keeps calls working
+// against servers that were not upgraded yet. No fallback is set by default
+func (clt *Client) SetResponseFormatFallback(format string) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.responseFormatFallback = format
+	})
+}
+
+// isResponseFormatUnsupported reports whether response rejects the response
+// format of its request while a fallback is set. The response body is left
+// readable from its start
+func (clt *Client) isResponseFormatUnsupported(response *http.Response) bool {
+	if clt.config().responseFormatFallback == "" || response.StatusCode < http.StatusBadRequest {
+		return false
+	}
+
+	peeked, err := peekBody(response, formatErrorPeekSize)
+	if err != nil {
+		return false
+	}
+
+	var errorBody struct {
+		Type string `json:"type"`
+	}
+	return json.Unmarshal(peeked, &errorBody) == nil && errorBody.Type == ErrorTypeGeneralFormatUnsupported
+}
@@ -0,0 +1,79 @@
+package appwrite
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recordFormats starts a test server rejecting the current ResponseFormat
+// once, and returning the response formats it received
+func recordFormats(t *testing.T) (Client, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var formats []string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		format := r.Header.Get("X-Appwrite-Response-Format")
+		mu.Lock()
+		formats = append(formats, format)
+		mu.Unlock()
+		if format == ResponseFormat {
+			writeJSON(w, http.StatusBadRequest, `{"message":"Unsupported response format","code":400,"type":"general_response_format_unsupported"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"ok":true}`)
+	})
+
+	return clt, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), formats...)
+	}
+}
+
+func TestSetResponseFormatFallback(t *testing.T) {
+	clt, formats := recordFormats(t)
+	clt.SetResponseFormatFallback("1.4.0")
+
+	result, err := clt.Call("POST", "/teams", nil, map[string]interface{}{"name": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["ok"] != true {
+		t.Fatalf("got %v", result)
+	}
+	if got, want := formats(), []string{ResponseFormat, "1.4.0"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("sent formats %q, want %q", got, want)
+	}
+}
+
+func TestResponseFormatWithoutFallback(t *testing.T) {
+	clt, formats := recordFormats(t)
+
+	_, err := clt.Call("POST", "/teams", nil, map[string]interface{}{"name": "a"})
+	if !IsType(err, ErrorTypeGeneralFormatUnsupported) {
+		t.Fatalf("got %v", err)
+	}
+	if got := formats(); len(got) != 1 {
+		t.Fatalf("sent formats %q", got)
+	}
+}
+
+func TestResponseFormatFallbackKeepsOtherErrors(t *testing.T) {
+	var calls int
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(w, http.StatusNotFound, `{"message":"Document not found","code":404,"type":"document_not_found"}`)
+	})
+	clt.SetResponseFormatFallback("1.4.0")
+
+	_, err := clt.Call("GET", "/databases/db/collections/c/documents/d", nil, nil)
+	if !IsType(err, ErrorTypeDocumentNotFound) {
+		t.Fatalf("got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("sent %d requests", calls)
+	}
+}
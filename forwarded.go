@@ -0,0 +1,45 @@
+package appwrite
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// ForwardedForHeader carries the IP address of the end user a server
+	// integration acts for, recorded by Appwrite in its audit logs
+	ForwardedForHeader = "X-Forwarded-For"
+
+	// ForwardedUserAgentHeader carries the user agent of the end user a
+	// server integration acts for
+	ForwardedUserAgentHeader = "X-Forwarded-User-Agent"
+)
+
+// Forwarded holds the details of the end user a call is made on behalf of.
+// Empty fields are not sent
+type Forwarded struct {
+	For       string
+	UserAgent string
+}
+
+type forwardedContextKey struct{}
+
+// ContextWithForwarded returns a copy of ctx carrying the forwarded details
+// of an end user. Calls made with the returned context send them in the
+// X-Forwarded-For and X-Forwarded-User-Agent headers, over any value set
+// client-wide with SetForwardedFor or SetForwardedUserAgent
+func ContextWithForwarded(ctx context.Context, forwarded Forwarded) context.Context {
+	return context.WithValue(ctx, forwardedContextKey{}, forwarded)
+}
+
+// setForwardedHeaders sets the forwarded headers carried by ctx, if any, on
+// req
+func setForwardedHeaders(ctx context.Context, req *http.Request) {
+	forwarded, _ := ctx.Value(forwardedContextKey{}).(Forwarded)
+	if forwarded.For != "" {
+		req.Header.Set(ForwardedForHeader, forwarded.For)
+	}
+	if forwarded.UserAgent != "" {
+		req.Header.Set(ForwardedUserAgentHeader, forwarded.UserAgent)
+	}
+}
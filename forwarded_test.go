@@ -0,0 +1,64 @@
+package appwrite
+
+import (
+	"context"
+	"testing"
+)
+
+func TestForwardedHeaders(t *testing.T) {
+	clt, headers := recordHeaders(t)
+	clt.SetForwardedFor("203.0.113.1")
+	clt.SetForwardedUserAgent("client-agent")
+
+	ctx := ContextWithForwarded(context.Background(), Forwarded{For: "198.51.100.7", UserAgent: "user-agent"})
+	if _, err := clt.CallWithContext(ctx, "GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := headers().Get(ForwardedForHeader); got != "198.51.100.7" {
+		t.Fatalf("sent forwarded for %q", got)
+	}
+	if got := headers().Get(ForwardedUserAgentHeader); got != "user-agent" {
+		t.Fatalf("sent forwarded user agent %q", got)
+	}
+
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := headers().Get(ForwardedForHeader); got != "203.0.113.1" {
+		t.Fatalf("sent forwarded for %q", got)
+	}
+	if got := headers().Get(ForwardedUserAgentHeader); got != "client-agent" {
+		t.Fatalf("sent forwarded user agent %q", got)
+	}
+}
+
+func TestForwardedHeadersKeepDefaults(t *testing.T) {
+	clt, headers := recordHeaders(t)
+
+	ctx := ContextWithForwarded(context.Background(), Forwarded{For: "198.51.100.7"})
+	if _, err := clt.CallWithContext(ctx, "GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := headers().Get(ForwardedForHeader); got != "198.51.100.7" {
+		t.Fatalf("sent forwarded for %q", got)
+	}
+	if got := headers().Get("X-Appwrite-Project"); got != "test" {
+		t.Fatalf("sent project %q", got)
+	}
+	if got := headers().Get(ForwardedUserAgentHeader); got != "" {
+		t.Fatalf("sent empty forwarded user agent as %q", got)
+	}
+}
+
+func TestForwardedSetters(t *testing.T) {
+	clt := NewClient()
+	clt.SetForwardedFor("203.0.113.1")
+	clt.SetForwardedUserAgent("client-agent")
+
+	if got := clt.header("X-Forwarded-For"); got != "203.0.113.1" {
+		t.Fatalf("got X-Forwarded-For %q", got)
+	}
+	if got := clt.header("X-Forwarded-User-Agent"); got != "client-agent" {
+		t.Fatalf("got X-Forwarded-User-Agent %q", got)
+	}
+}
@@ -0,0 +1,162 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Functions service
+type Functions struct {
+	client Client
+}
+
+func NewFunctions(clt Client) Functions {
+	service := Functions{
+		client: clt,
+	}
+
+	return service
+}
+
+// ListExecutions get a list of all the current user function execution
+// logs. You can use the query params to filter your results.
+func (srv *Functions) ListExecutions(FunctionId string, Queries []string, Search string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{functionId}", url.PathEscape(FunctionId))
+	path := r.Replace("/functions/{functionId}/executions")
+
+	params := map[string]interface{}{
+		"queries": Queries,
+		"search":  Search,
+	}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// CreateExecution trigger a function execution. The returned object will
+// return you the current execution status. You can ping the `Get Execution`
+// endpoint to get updates on the current execution status. Once this
+// endpoint is called, your function execution process will start
+// asynchronously.
+func (srv *Functions) CreateExecution(FunctionId string, Body string, Async bool, Path string, Method string, Headers map[string]string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{functionId}", url.PathEscape(FunctionId))
+	path := r.Replace("/functions/{functionId}/executions")
+
+	params := map[string]interface{}{
+		"body":    Body,
+		"async":   Async,
+		"path":    Path,
+		"method":  Method,
+		"headers": Headers,
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// GetExecution get a function execution log by its unique ID. The returned
+// execution includes its status, responseStatusCode, responseBody and the
+// stdout/stderr logs.
+func (srv *Functions) GetExecution(FunctionId string, ExecutionId string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{functionId}", url.PathEscape(FunctionId), "{executionId}", url.PathEscape(ExecutionId))
+	path := r.Replace("/functions/{functionId}/executions/{executionId}")
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// defaultExecutionPoll is how often WaitForExecution polls unless told
+// otherwise
+const defaultExecutionPoll = time.Second
+
+// WaitForExecution get a function execution log by its unique ID, polling
+// every Poll until the execution is completed or failed, and returns the
+// final execution. A Poll of zero or less polls every second. Polling stops
+// with the context error once ctx is done.
+func (srv *Functions) WaitForExecution(ctx context.Context, FunctionId string, ExecutionId string, Poll time.Duration) (map[string]interface{}, error) {
+	if Poll <= 0 {
+		Poll = defaultExecutionPoll
+	}
+
+	r := strings.NewReplacer("{functionId}", url.PathEscape(FunctionId), "{executionId}", url.PathEscape(ExecutionId))
+	path := r.Replace("/functions/{functionId}/executions/{executionId}")
+
+	for {
+		execution, err := srv.client.CallWithContext(ctx, "GET", path, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		switch ToString(execution["status"]) {
+		case "completed", "failed":
+			return execution, nil
+		}
+
+		if err := sleepContext(ctx, Poll); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ExecutionError is the error returned when a function execution did not
+// complete
+type ExecutionError struct {
+	ExecutionId        string
+	Status             string
+	ResponseStatusCode int
+	Errors             string
+}
+
+func (e *ExecutionError) Error() string {
+	if e.Errors != "" {
+		return fmt.Sprintf("execution %s %s: %s", e.ExecutionId, e.Status, e.Errors)
+	}
+	return fmt.Sprintf("execution %s %s", e.ExecutionId, e.Status)
+}
+
+// ExecuteAndDecode runs a function synchronously and decodes the JSON
+// responseBody of the execution into out. Body is sent as is when it is a
+// string and JSON-encoded otherwise. An execution that did not complete is
+// returned as an ExecutionError. The call is bounded by ctx.
+func ExecuteAndDecode[T any](ctx context.Context, srv *Functions, FunctionId string, Body interface{}, out *T) error {
+	body, ok := Body.(string)
+	if !ok && Body != nil {
+		encoded, err := json.Marshal(Body)
+		if err != nil {
+			return err
+		}
+		body = string(encoded)
+	}
+
+	r := strings.NewReplacer("{functionId}", url.PathEscape(FunctionId))
+	path := r.Replace("/functions/{functionId}/executions")
+
+	params := map[string]interface{}{
+		"body":  body,
+		"async": false,
+	}
+
+	execution, err := srv.client.CallWithContext(ctx, "POST", path, nil, params)
+	if err != nil {
+		return err
+	}
+
+	if status := ToString(execution["status"]); status != "completed" {
+		statusCode, _ := execution["responseStatusCode"].(float64)
+		return &ExecutionError{
+			ExecutionId:        ToString(execution["$id"]),
+			Status:             status,
+			ResponseStatusCode: int(statusCode),
+			Errors:             ToString(execution["errors"]),
+		}
+	}
+
+	responseBody := ToString(execution["responseBody"])
+	if responseBody == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(responseBody), out)
+}
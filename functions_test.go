@@ -0,0 +1,152 @@
+package appwrite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFunctionsCreateExecution(t *testing.T) {
+	clt, last := recordRequests(t, `{"$id":"e1","status":"completed","responseStatusCode":200,"stdout":"ok","stderr":""}`)
+	srv := NewFunctions(clt)
+
+	headers := map[string]string{"X-Trace": "abc"}
+	execution, err := srv.CreateExecution("fn", `{"a":1}`, true, "/hooks", "PUT", headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execution["responseStatusCode"] != float64(200) || execution["stdout"] != "ok" {
+		t.Fatalf("got %v", execution)
+	}
+
+	req := last()
+	if req.Method != "POST" || req.Path != "/v1/functions/fn/executions" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	want := map[string]interface{}{
+		"body":    `{"a":1}`,
+		"async":   true,
+		"path":    "/hooks",
+		"method":  "PUT",
+		"headers": map[string]interface{}{"X-Trace": "abc"},
+	}
+	if !reflect.DeepEqual(req.Body, want) {
+		t.Fatalf("sent %v", req.Body)
+	}
+}
+
+func TestFunctionsExecutionPaths(t *testing.T) {
+	clt, last := recordRequests(t, `{}`)
+	srv := NewFunctions(clt)
+
+	srv.GetExecution("fn", "e/1")
+	if req := last(); req.Method != "GET" || req.Path != "/v1/functions/fn/executions/e%2F1" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+
+	srv.ListExecutions("fn", []string{Query{}.Limit(5)}, "failed")
+	req := last()
+	if req.Method != "GET" || req.Path != "/v1/functions/fn/executions" || req.Query.Get("search") != "failed" || len(req.Query["queries[]"]) != 1 {
+		t.Fatalf("sent %s %s?%v", req.Method, req.Path, req.Query)
+	}
+}
+
+func TestExecuteAndDecode(t *testing.T) {
+	clt, last := recordRequests(t, `{"$id":"e1","status":"completed","responseStatusCode":200,"responseBody":"{\"total\":3}"}`)
+	srv := NewFunctions(clt)
+
+	var out struct {
+		Total int `json:"total"`
+	}
+	if err := ExecuteAndDecode(context.Background(), &srv, "fn", map[string]int{"a": 1}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Total != 3 {
+		t.Fatalf("decoded %+v", out)
+	}
+
+	req := last()
+	if req.Method != "POST" || req.Path != "/v1/functions/fn/executions" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if req.Body["body"] != `{"a":1}` || req.Body["async"] != false {
+		t.Fatalf("sent %v", req.Body)
+	}
+}
+
+func TestExecuteAndDecodeFailed(t *testing.T) {
+	clt, _ := recordRequests(t, `{"$id":"e1","status":"failed","responseStatusCode":500,"errors":"boom"}`)
+	srv := NewFunctions(clt)
+
+	var out map[string]interface{}
+	err := ExecuteAndDecode(context.Background(), &srv, "fn", "payload", &out)
+	var execution *ExecutionError
+	if !errors.As(err, &execution) {
+		t.Fatalf("got %v", err)
+	}
+	if execution.ExecutionId != "e1" || execution.Status != "failed" || execution.ResponseStatusCode != 500 || execution.Errors != "boom" {
+		t.Fatalf("got %+v", execution)
+	}
+}
+
+func TestExecuteAndDecodeDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	srv := NewFunctions(clt)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var out map[string]interface{}
+	if err := ExecuteAndDecode(ctx, &srv, "fn", nil, &out); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestWaitForExecution(t *testing.T) {
+	var calls int32
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/functions/fn/executions/e1" {
+			t.Errorf("got path %q", r.URL.Path)
+		}
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			writeJSON(w, http.StatusOK, `{"$id":"e1","status":"processing"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"$id":"e1","status":"completed","stdout":"done"}`)
+	})
+	srv := NewFunctions(clt)
+
+	execution, err := srv.WaitForExecution(context.Background(), "fn", "e1", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if execution["status"] != "completed" || execution["stdout"] != "done" {
+		t.Fatalf("got %v", execution)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("polled %d times", got)
+	}
+}
+
+func TestWaitForExecutionDeadline(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"$id":"e1","status":"processing"}`)
+	})
+	srv := NewFunctions(clt)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := srv.WaitForExecution(ctx, "fn", "e1", 5*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v", err)
+	}
+}
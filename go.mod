@@ -0,0 +1,3 @@
+module github.com/appwrite/sdk-for-go
+
+go 1.21
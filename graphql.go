@@ -0,0 +1,86 @@
+package appwrite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Graphql service
+type Graphql struct {
+	client Client
+}
+
+func NewGraphql(clt Client) Graphql {
+	service := Graphql{
+		client: clt,
+	}
+
+	return service
+}
+
+// GraphqlException is the error returned when a GraphQL response holds a
+// non-empty errors array
+type GraphqlException struct {
+	Errors []map[string]interface{}
+}
+
+func (e *GraphqlException) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, gqlError := range e.Errors {
+		messages = append(messages, fmt.Sprint(gqlError["message"]))
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// Query execute a GraphQL query. The returned map holds the data and errors
+// of the response, and GraphQL errors are also returned as a
+// GraphqlException.
+func (srv *Graphql) Query(Query string, Variables map[string]interface{}) (map[string]interface{}, error) {
+	path := "/graphql"
+
+	params := map[string]interface{}{
+		"query":     Query,
+		"variables": Variables,
+	}
+
+	return srv.call(path, params)
+}
+
+// Mutation execute a GraphQL mutation. The returned map holds the data and
+// errors of the response, and GraphQL errors are also returned as a
+// GraphqlException.
+func (srv *Graphql) Mutation(Query string, Variables map[string]interface{}) (map[string]interface{}, error) {
+	path := "/graphql/mutation"
+
+	params := map[string]interface{}{
+		"query":     Query,
+		"variables": Variables,
+	}
+
+	return srv.call(path, params)
+}
+
+func (srv *Graphql) call(path string, params map[string]interface{}) (map[string]interface{}, error) {
+	headers := map[string]interface{}{
+		"x-sdk-graphql": "true",
+	}
+
+	response, err := srv.client.Call("POST", path, headers, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if gqlErrors, ok := response["errors"].([]interface{}); ok && len(gqlErrors) > 0 {
+		exception := &GraphqlException{}
+		for _, gqlError := range gqlErrors {
+			if entry, ok := gqlError.(map[string]interface{}); ok {
+				exception.Errors = append(exception.Errors, entry)
+			} else {
+				exception.Errors = append(exception.Errors, map[string]interface{}{"message": gqlError})
+			}
+		}
+		return response, exception
+	}
+
+	return response, nil
+}
@@ -0,0 +1,47 @@
+package appwrite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGraphqlQuery(t *testing.T) {
+	clt, last := recordRequests(t, `{"data":{"accountGet":{"_id":"u1"}}}`)
+	srv := NewGraphql(clt)
+
+	variables := map[string]interface{}{"id": "u1"}
+	result, err := srv.Query("query { accountGet { _id } }", variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := result["data"].(map[string]interface{}); data["accountGet"] == nil {
+		t.Fatalf("got %v", result)
+	}
+
+	req := last()
+	if req.Method != "POST" || req.Path != "/v1/graphql" || req.Header.Get("X-Sdk-Graphql") != "true" {
+		t.Fatalf("sent %s %s with headers %v", req.Method, req.Path, req.Header)
+	}
+	want := map[string]interface{}{"query": "query { accountGet { _id } }", "variables": variables}
+	if !reflect.DeepEqual(req.Body, want) {
+		t.Fatalf("sent %v", req.Body)
+	}
+}
+
+func TestGraphqlErrors(t *testing.T) {
+	clt, last := recordRequests(t, `{"data":null,"errors":[{"message":"Field \"nope\" not found"},{"message":"Syntax error"}]}`)
+	srv := NewGraphql(clt)
+
+	result, err := srv.Mutation("mutation { nope }", nil)
+	var exception *GraphqlException
+	if !errors.As(err, &exception) || len(exception.Errors) != 2 {
+		t.Fatalf("got %v", err)
+	}
+	if err.Error() != `graphql: Field "nope" not found; Syntax error` {
+		t.Fatalf("got message %q", err.Error())
+	}
+	if result == nil || last().Path != "/v1/graphql/mutation" {
+		t.Fatalf("got %v from %s", result, last().Path)
+	}
+}
@@ -0,0 +1,63 @@
+package appwrite
+
+// Health service
+type Health struct {
+	client Client
+}
+
+func NewHealth(clt Client) Health {
+	service := Health{
+		client: clt,
+	}
+
+	return service
+}
+
+// Get check the Appwrite HTTP server is up and responsive.
+func (srv *Health) Get() (map[string]interface{}, error) {
+	path := "/health"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// GetDB check the Appwrite database servers are up and connection is
+// successful.
+func (srv *Health) GetDB() (map[string]interface{}, error) {
+	path := "/health/db"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// GetCache check the Appwrite in-memory cache servers are up and connection
+// is successful.
+func (srv *Health) GetCache() (map[string]interface{}, error) {
+	path := "/health/cache"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// GetStorageLocal check the Appwrite local storage device is up and
+// connection is successful.
+func (srv *Health) GetStorageLocal() (map[string]interface{}, error) {
+	path := "/health/storage/local"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// GetQueueWebhooks get the number of webhooks that are waiting to be
+// processed in the Appwrite internal queue server.
+func (srv *Health) GetQueueWebhooks() (map[string]interface{}, error) {
+	path := "/health/queue/webhooks"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
@@ -0,0 +1,37 @@
+package appwrite
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.Header.Get("X-Appwrite-Project") != "test" {
+			t.Errorf("sent %s with project %q", r.Method, r.Header.Get("X-Appwrite-Project"))
+		}
+		writeJSON(w, http.StatusOK, `{"status":"pass","ping":3,"path":"`+r.URL.Path+`"}`)
+	})
+	srv := NewHealth(clt)
+
+	tests := []struct {
+		name string
+		call func() (map[string]interface{}, error)
+		path string
+	}{
+		{"Get", srv.Get, "/v1/health"},
+		{"GetDB", srv.GetDB, "/v1/health/db"},
+		{"GetCache", srv.GetCache, "/v1/health/cache"},
+		{"GetStorageLocal", srv.GetStorageLocal, "/v1/health/storage/local"},
+		{"GetQueueWebhooks", srv.GetQueueWebhooks, "/v1/health/queue/webhooks"},
+	}
+	for _, test := range tests {
+		result, err := test.call()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if result["status"] != "pass" || result["path"] != test.path {
+			t.Errorf("%s: got %v, want path %s", test.name, result, test.path)
+		}
+	}
+}
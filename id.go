@@ -1,11 +1,26 @@
 package appwrite
 
+import (
+	"errors"
+	"regexp"
+)
+
+// ID builds the resource ids accepted by create endpoints
 type ID struct{}
 
-func (i ID) Custom(id string) string {
-	return id
+var customIdPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,35}$`)
+
+// Custom validates a user provided id. Valid ids are 1 to 36 characters of
+// a-z, A-Z, 0-9, period, hyphen and underscore, and can't start with a
+// special character
+func (i ID) Custom(id string) (string, error) {
+	if !customIdPattern.MatchString(id) {
+		return "", errors.New("invalid id: must be 1-36 chars of a-z, A-Z, 0-9, '.', '-' or '_' and can't start with a special char")
+	}
+	return id, nil
 }
 
+// Unique asks the server to generate a unique id
 func (i ID) Unique() string {
 	return "unique()"
 }
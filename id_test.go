@@ -0,0 +1,28 @@
+package appwrite
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIDUnique(t *testing.T) {
+	if got := (ID{}).Unique(); got != "unique()" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestIDCustom(t *testing.T) {
+	valid := []string{"a", "user_1", "doc.2024-01", strings.Repeat("a", 36)}
+	for _, id := range valid {
+		if got, err := (ID{}).Custom(id); err != nil || got != id {
+			t.Errorf("Custom(%q) = %q, %v", id, got, err)
+		}
+	}
+
+	invalid := []string{"", strings.Repeat("a", 37), "_user", ".doc", "-x", "with space", "é"}
+	for _, id := range invalid {
+		if _, err := (ID{}).Custom(id); err == nil {
+			t.Errorf("Custom(%q) accepted an invalid id", id)
+		}
+	}
+}
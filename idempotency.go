@@ -0,0 +1,8 @@
+package appwrite
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a call,
+// letting the server drop duplicates of a request it already handled. Set
+// it with CallOptions.IdempotencyKey, or pass it in the headers of a single
+// call: the key then belongs to that call only and is reused on each of its
+// automatic retries, never on other requests
+const IdempotencyKeyHeader = "X-Appwrite-Idempotency-Key"
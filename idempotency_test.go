@@ -0,0 +1,65 @@
+package appwrite
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordIdempotencyKeys starts a test server failing the first failures
+// requests with a 503, and recording the idempotency key of every request
+func recordIdempotencyKeys(t *testing.T, failures int) (Client, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var keys []string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		n := len(keys)
+		mu.Unlock()
+
+		if n <= failures {
+			writeJSON(w, http.StatusServiceUnavailable, `{"message":"unavailable","code":503}`)
+			return
+		}
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+
+	return clt, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestIdempotencyKeyReusedAcrossRetries(t *testing.T) {
+	clt, keys := recordIdempotencyKeys(t, 2)
+	clt.SetRetry(3, time.Millisecond)
+
+	if _, err := clt.CallWithOptions("POST", "/teams", map[string]interface{}{"name": "a"}, CallOptions{IdempotencyKey: "key-1"}); err != nil {
+		t.Fatal(err)
+	}
+	got := keys()
+	if len(got) != 3 {
+		t.Fatalf("made %d attempts", len(got))
+	}
+	for i, key := range got {
+		if key != "key-1" {
+			t.Fatalf("attempt %d sent key %q", i+1, key)
+		}
+	}
+}
+
+func TestIdempotencyKeyBelongsToOneCall(t *testing.T) {
+	clt, keys := recordIdempotencyKeys(t, 0)
+
+	headers := map[string]interface{}{IdempotencyKeyHeader: "key-1"}
+	clt.Call("POST", "/teams", headers, map[string]interface{}{"name": "a"})
+	clt.Call("POST", "/teams", nil, map[string]interface{}{"name": "b"})
+
+	if got := keys(); len(got) != 2 || got[0] != "key-1" || got[1] != "" {
+		t.Fatalf("sent keys %q", got)
+	}
+}
@@ -0,0 +1,191 @@
+package appwrite
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// InputFile is a file passed as a param to be uploaded with a
+// multipart/form-data request
+type InputFile struct {
+	Name   string
+	Reader io.Reader
+
+	// MimeType is the Content-Type of the file part. When empty it is
+	// guessed from the extension of Name, defaulting to
+	// application/octet-stream
+	MimeType string
+}
+
+// NewInputFile creates an InputFile reading its content from reader
+func NewInputFile(name string, reader io.Reader) InputFile {
+	return InputFile{
+		Name:   name,
+		Reader: reader,
+	}
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// contentType returns the MIME type of the file
+func (file InputFile) contentType() string {
+	if file.MimeType != "" {
+		return file.MimeType
+	}
+	if mimeType := mime.TypeByExtension(filepath.Ext(file.Name)); mimeType != "" {
+		return mimeType
+	}
+	return "application/octet-stream"
+}
+
+// partHeader returns the header of the multipart part holding the file
+func (file InputFile) partHeader(key string) textproto.MIMEHeader {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(key), quoteEscaper.Replace(file.Name)))
+	header.Set("Content-Type", file.contentType())
+	return header
+}
+
+// withSize returns the file along with the number of bytes left to read from
+// it. A reader implementing io.Seeker is measured by seeking to its end and
+// back, any other reader is read into memory, buffered reporting so, and the
+// returned InputFile reads from that buffer instead
+func (file InputFile) withSize() (InputFile, int64, bool, error) {
+	if seeker, ok := file.Reader.(io.Seeker); ok {
+		current, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return file, 0, false, err
+		}
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return file, 0, false, err
+		}
+		if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+			return file, 0, false, err
+		}
+		return file, end - current, false, nil
+	}
+
+	data, err := io.ReadAll(file.Reader)
+	if err != nil {
+		return file, 0, true, err
+	}
+	file.Reader = bytes.NewReader(data)
+	return file, int64(len(data)), true, nil
+}
+
+// rewindableFiles returns a copy of params whose files can be read again
+// from their start by each attempt of a call, along with the function to
+// call before each attempt. A file reader implementing io.Seeker is sought
+// back to its current offset, any other reader is buffered in memory once
+func rewindableFiles(params map[string]interface{}) (map[string]interface{}, func() error, error) {
+	if !hasInputFile(params) {
+		return params, func() error { return nil }, nil
+	}
+
+	var seekers []io.Seeker
+	var offsets []int64
+	rewindable := copyParams(params)
+	for key, val := range params {
+		file, ok := asInputFile(val)
+		if !ok {
+			continue
+		}
+
+		seeker, ok := file.Reader.(io.Seeker)
+		if !ok {
+			data, err := io.ReadAll(file.Reader)
+			if err != nil {
+				return nil, nil, err
+			}
+			reader := bytes.NewReader(data)
+			file.Reader, seeker = reader, reader
+		}
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		seekers = append(seekers, seeker)
+		offsets = append(offsets, offset)
+		rewindable[key] = file
+	}
+
+	rewind := func() error {
+		for i, seeker := range seekers {
+			if _, err := seeker.Seek(offsets[i], io.SeekStart); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return rewindable, rewind, nil
+}
+
+// asInputFile returns the InputFile held by a param value, if any
+func asInputFile(val interface{}) (InputFile, bool) {
+	switch v := val.(type) {
+	case InputFile:
+		return v, true
+	case *InputFile:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return InputFile{}, false
+}
+
+// hasInputFile reports whether params contain a file to upload
+func hasInputFile(params map[string]interface{}) bool {
+	for _, val := range params {
+		if _, ok := asInputFile(val); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareMultipartBody encodes params as a multipart/form-data body, writing
+// files as file parts and every other param as form fields. It returns the
+// body along with its Content-Type, including the boundary
+func prepareMultipartBody(params map[string]interface{}) (io.Reader, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for key, val := range omitNilParams(params) {
+		if file, ok := asInputFile(val); ok {
+			part, err := writer.CreatePart(file.partHeader(key))
+			if err != nil {
+				return nil, "", err
+			}
+			if _, err := io.Copy(part, file.Reader); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+
+		// Lists and objects are expanded the way query parameters are
+		fields := url.Values{}
+		addQueryParameter(fields, key, val)
+		for name, values := range fields {
+			for _, value := range values {
+				if err := writer.WriteField(name, value); err != nil {
+					return nil, "", err
+				}
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
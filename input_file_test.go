@@ -0,0 +1,87 @@
+package appwrite
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCallSendsMultipartFiles(t *testing.T) {
+	var content, filename, fileId string
+	var permissions []string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		content, filename = string(data), header.Filename
+		fileId, permissions = r.FormValue("fileId"), r.MultipartForm.Value["permissions[]"]
+		writeJSON(w, http.StatusCreated, `{"$id":"f"}`)
+	})
+
+	params := map[string]interface{}{
+		"file":        NewInputFile("hello.txt", strings.NewReader("hello")),
+		"fileId":      "f",
+		"permissions": []interface{}{`read("any")`, `delete("users")`},
+	}
+	if _, err := clt.Call("POST", "/storage/buckets/b/files", nil, params); err != nil {
+		t.Fatal(err)
+	}
+
+	if content != "hello" || filename != "hello.txt" || fileId != "f" {
+		t.Fatalf("received %q named %q with file id %q", content, filename, fileId)
+	}
+	if !reflect.DeepEqual(permissions, []string{`read("any")`, `delete("users")`}) {
+		t.Fatalf("received permissions %q", permissions)
+	}
+}
+
+func TestInputFileContentType(t *testing.T) {
+	tests := []struct {
+		file InputFile
+		want string
+	}{
+		{InputFile{Name: "photo.png"}, "image/png"},
+		{InputFile{Name: "data"}, "application/octet-stream"},
+		{InputFile{Name: "photo.png", MimeType: "image/webp"}, "image/webp"},
+	}
+	for _, test := range tests {
+		if got := test.file.contentType(); got != test.want {
+			t.Errorf("contentType of %+v = %q, want %q", test.file, got, test.want)
+		}
+	}
+}
+
+func TestCallSendsPartContentType(t *testing.T) {
+	var contentType, filename string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		contentType, filename = header.Header.Get("Content-Type"), header.Filename
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+
+	tests := []struct {
+		file     InputFile
+		filename string
+	}{
+		{InputFile{Name: `a"b.bin`, Reader: strings.NewReader("x"), MimeType: "image/png"}, `a"b.bin`},
+		{NewInputFile("a.png", strings.NewReader("x")), "a.png"},
+	}
+	for _, test := range tests {
+		params := map[string]interface{}{"file": test.file}
+		if _, err := clt.Call("POST", "/storage/buckets/b/files", nil, params); err != nil {
+			t.Fatal(err)
+		}
+		if contentType != "image/png" || filename != test.filename {
+			t.Errorf("received %q named %q", contentType, filename)
+		}
+	}
+}
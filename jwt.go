@@ -0,0 +1,58 @@
+package appwrite
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrMalformedJWT is returned by DecodeJWT for a token that is not a JWT
+var ErrMalformedJWT = errors.New("malformed jwt")
+
+// JWTClaims are the claims of an Appwrite JWT, such as userId, sessionId and
+// exp
+type JWTClaims map[string]interface{}
+
+// DecodeJWT decodes the claims of a JWT created with account.createJWT. The
+// signature is not verified, as only the server holds the secret, so the
+// claims must not be trusted for anything beyond avoiding calls bound to fail
+func DecodeJWT(token string) (JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedJWT
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, ErrMalformedJWT
+	}
+
+	var claims JWTClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrMalformedJWT
+	}
+	return claims, nil
+}
+
+// UserID returns the ID of the user the JWT belongs to
+func (claims JWTClaims) UserID() string {
+	return ToString(claims["userId"])
+}
+
+// ExpiresAt returns the expiry time of the JWT, and false when it has none
+func (claims JWTClaims) ExpiresAt() (time.Time, bool) {
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0), true
+}
+
+// IsExpired reports whether the JWT has expired. A JWT without an expiry
+// never does
+func (claims JWTClaims) IsExpired() bool {
+	expiresAt, ok := claims.ExpiresAt()
+	return ok && !time.Now().Before(expiresAt)
+}
@@ -0,0 +1,50 @@
+package appwrite
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT for userId expiring at exp
+func testJWT(userId string, exp time.Time) string {
+	payload := fmt.Sprintf(`{"userId":%q,"sessionId":"s1","exp":%d}`, userId, exp.Unix())
+	return "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestDecodeJWT(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	claims, err := DecodeJWT(testJWT("u1", exp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := claims.UserID(); got != "u1" {
+		t.Fatalf("got user id %q", got)
+	}
+	if got, ok := claims.ExpiresAt(); !ok || !got.Equal(exp) {
+		t.Fatalf("got expiry %v, %v", got, ok)
+	}
+	if claims.IsExpired() {
+		t.Fatal("a valid JWT is expired")
+	}
+}
+
+func TestDecodeJWTExpired(t *testing.T) {
+	claims, err := DecodeJWT(testJWT("u1", time.Now().Add(-time.Minute)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !claims.IsExpired() {
+		t.Fatal("an expired JWT is not expired")
+	}
+}
+
+func TestDecodeJWTMalformed(t *testing.T) {
+	for _, token := range []string{"", "abc", "a.b", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("not json")) + ".c"} {
+		if _, err := DecodeJWT(token); !errors.Is(err, ErrMalformedJWT) {
+			t.Errorf("DecodeJWT(%q) = %v", token, err)
+		}
+	}
+}
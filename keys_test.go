@@ -0,0 +1,108 @@
+package appwrite
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recordKeys starts a test server recording the API key of each request
+func recordKeys(t *testing.T) (Client, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var keys []string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("X-Appwrite-Key"))
+		mu.Unlock()
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	return clt, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestSetKeysRotatesKeys(t *testing.T) {
+	clt, keys := recordKeys(t)
+	clt.SetKeys([]string{"a", "b", "c"})
+
+	srv := NewHealth(clt)
+	for i := 0; i < 4; i++ {
+		if _, err := srv.Get(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := keys(); !reflect.DeepEqual(got, []string{"a", "b", "c", "a"}) {
+		t.Fatalf("sent keys %q", got)
+	}
+}
+
+func TestSetKeysReachesExistingServices(t *testing.T) {
+	clt, keys := recordKeys(t)
+	clt.SetKey("old")
+	srv := NewHealth(clt)
+
+	clt.SetKeys([]string{"a", "b"})
+	srv.Get()
+	srv.Get()
+	clt.SetKey("z")
+	srv.Get()
+
+	if got := keys(); !reflect.DeepEqual(got, []string{"a", "b", "z"}) {
+		t.Fatalf("sent keys %q", got)
+	}
+}
+
+func TestSetKeysConcurrentCalls(t *testing.T) {
+	clt, keys := recordKeys(t)
+	clt.SetKeys([]string{"a", "b"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clt.Call("GET", "/health", nil, nil)
+		}()
+	}
+	wg.Wait()
+
+	counts := map[string]int{}
+	for _, key := range keys() {
+		counts[key]++
+	}
+	if counts["a"] != 10 || counts["b"] != 10 {
+		t.Fatalf("sent keys %v", counts)
+	}
+}
+
+func TestCallHeaderOverridesRotatedKey(t *testing.T) {
+	clt, keys := recordKeys(t)
+	clt.SetKeys([]string{"a", "b"})
+
+	clt.Call("GET", "/health", map[string]interface{}{"X-Appwrite-Key": "own"}, nil)
+	if got := keys(); !reflect.DeepEqual(got, []string{"own"}) {
+		t.Fatalf("sent keys %q", got)
+	}
+}
+
+func TestSetKeyWithName(t *testing.T) {
+	clt, keys := recordKeys(t)
+
+	clt.SetKey("secret")
+	clt.Call("GET", "/health", nil, nil)
+	clt.SetKeyWithName("dynamic", "secret")
+	clt.Call("GET", "/health", nil, nil)
+	clt.SetKeyWithName("", "secret")
+	clt.Call("GET", "/health", nil, nil)
+
+	want := []string{"secret", "dynamic_secret", "secret"}
+	if got := keys(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("sent keys %q, want %q", got, want)
+	}
+}
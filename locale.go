@@ -29,6 +29,8 @@ func (srv *Locale) Get() (map[string]interface{}, error) {
 
 // GetContinents list of all continents. You can use the locale header to get
 // the data in a supported language.
+//
+// Deprecated: use ListContinents instead.
 func (srv *Locale) GetContinents() (map[string]interface{}, error) {
 	path := "/locale/continents"
 
@@ -39,6 +41,8 @@ func (srv *Locale) GetContinents() (map[string]interface{}, error) {
 
 // GetCountries list of all countries. You can use the locale header to get
 // the data in a supported language.
+//
+// Deprecated: use ListCountries instead.
 func (srv *Locale) GetCountries() (map[string]interface{}, error) {
 	path := "/locale/countries"
 
@@ -70,6 +74,8 @@ func (srv *Locale) GetCountriesPhones() (map[string]interface{}, error) {
 // GetCurrencies list of all currencies, including currency symol, name,
 // plural, and decimal digits for all major and minor currencies. You can use
 // the locale header to get the data in a supported language.
+//
+// Deprecated: use ListCurrencies instead.
 func (srv *Locale) GetCurrencies() (map[string]interface{}, error) {
 	path := "/locale/currencies"
 
@@ -77,3 +83,44 @@ func (srv *Locale) GetCurrencies() (map[string]interface{}, error) {
 
 	return srv.client.Call("GET", path, nil, params)
 }
+
+// ListContinents list of all continents. You can use the locale header to
+// get the data in a supported language.
+func (srv *Locale) ListContinents() (map[string]interface{}, error) {
+	path := "/locale/continents"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// ListCountries list of all countries. You can use the locale header to get
+// the data in a supported language.
+func (srv *Locale) ListCountries() (map[string]interface{}, error) {
+	path := "/locale/countries"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// ListCurrencies list of all currencies, including currency symbol, name,
+// plural, and decimal digits for all major and minor currencies. You can use
+// the locale header to get the data in a supported language.
+func (srv *Locale) ListCurrencies() (map[string]interface{}, error) {
+	path := "/locale/currencies"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
+
+// ListLanguages list of all languages classified by ISO 639-1 including
+// 2-letter code, name in English, and name in the respective language.
+func (srv *Locale) ListLanguages() (map[string]interface{}, error) {
+	path := "/locale/languages"
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
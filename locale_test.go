@@ -0,0 +1,40 @@
+package appwrite
+
+import (
+	"testing"
+)
+
+func TestLocaleForwardsLocaleHeader(t *testing.T) {
+	clt, last := recordRequests(t, `{"total":1,"countries":[{"name":"France","code":"FR"}]}`)
+	clt.SetLocale("fr-FR")
+	srv := NewLocale(clt)
+
+	tests := []struct {
+		name string
+		call func() (map[string]interface{}, error)
+		path string
+	}{
+		{"Get", srv.Get, "/v1/locale"},
+		{"ListCountries", srv.ListCountries, "/v1/locale/countries"},
+		{"ListCurrencies", srv.ListCurrencies, "/v1/locale/currencies"},
+		{"ListLanguages", srv.ListLanguages, "/v1/locale/languages"},
+		{"ListContinents", srv.ListContinents, "/v1/locale/continents"},
+	}
+	for _, test := range tests {
+		result, err := test.call()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if result["total"] != float64(1) {
+			t.Errorf("%s: got %v", test.name, result)
+		}
+
+		req := last()
+		if req.Method != "GET" || req.Path != test.path {
+			t.Errorf("%s: sent %s %s, want GET %s", test.name, req.Method, req.Path, test.path)
+		}
+		if got := req.Header.Get("X-Appwrite-Locale"); got != "fr-FR" {
+			t.Errorf("%s: sent locale %q", test.name, got)
+		}
+	}
+}
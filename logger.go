@@ -0,0 +1,94 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces secret values in logged request info
+const redacted = "[REDACTED]"
+
+// sensitiveParams lists the params whose values are never logged
+var sensitiveParams = []string{"password", "secret", "key", "token", "hash", "jwt"}
+
+// SetLogger sets a function receiving a line of request info for each
+// request the Client sends, with passwords, secrets and keys redacted.
+// Nothing is logged unless a logger is set
+func (clt *Client) SetLogger(logger func(msg string)) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.logger = logger
+	})
+}
+
+// logRequest logs req to the logger, if any. The params are only logged for
+// requests sending them in a body, GET params being part of the URL
+func (clt *Client) logRequest(req *http.Request, params map[string]interface{}) {
+	logger := clt.config().logger
+	if logger == nil {
+		return
+	}
+
+	msg := req.Method + " " + redactURL(req.URL)
+	if req.Method != "GET" && len(params) > 0 && !hasInputFile(params) {
+		if data, err := json.Marshal(redactParams(params)); err == nil {
+			msg += " " + string(data)
+		}
+	}
+	logger(msg)
+}
+
+// redactURL returns u as a string with the values of sensitive query params
+// redacted
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	pairs := strings.Split(u.RawQuery, "&")
+	for i, pair := range pairs {
+		rawName, _, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(rawName)
+		if err != nil {
+			name = rawName
+		}
+		if isSensitive(name) {
+			pairs[i] = rawName + "=" + redacted
+		}
+	}
+
+	redactedURL := *u
+	redactedURL.RawQuery = strings.Join(pairs, "&")
+	return redactedURL.String()
+}
+
+// isSensitive reports whether a param or header name holds a secret
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveParams {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactParams returns a copy of params with the values of sensitive params
+// replaced, looking into nested maps
+func redactParams(params map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(params))
+	for key, val := range params {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			copied[key] = redactParams(v)
+		default:
+			if isSensitive(key) {
+				copied[key] = redacted
+			} else {
+				copied[key] = val
+			}
+		}
+	}
+	return copied
+}
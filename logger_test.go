@@ -0,0 +1,76 @@
+package appwrite
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNothingLoggedByDefault(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+
+	var output bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&output)
+
+	params := map[string]interface{}{"email": "a@b.c", "password": "hunter2"}
+	if _, err := clt.Call("POST", "/account/sessions/email", nil, params); err != nil {
+		t.Fatal(err)
+	}
+	if output.Len() != 0 {
+		t.Fatalf("logged %q", output.String())
+	}
+}
+
+func TestSetLoggerRedactsSecrets(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+
+	var messages []string
+	clt.SetLogger(func(msg string) { messages = append(messages, msg) })
+
+	params := map[string]interface{}{
+		"email":    "a@b.c",
+		"password": "hunter2",
+		"data":     map[string]interface{}{"apiSecret": "s3cr3t"},
+	}
+	if _, err := clt.Call("POST", "/account/sessions/email", nil, params); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 1 || !strings.HasPrefix(messages[0], "POST ") || !strings.Contains(messages[0], "a@b.c") {
+		t.Fatalf("logged %q", messages)
+	}
+	if strings.Contains(messages[0], "hunter2") || strings.Contains(messages[0], "s3cr3t") {
+		t.Fatalf("logged a secret: %q", messages[0])
+	}
+}
+
+func TestSetLoggerRedactsQuery(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	var messages []string
+	clt.SetLogger(func(msg string) { messages = append(messages, msg) })
+
+	params := map[string]interface{}{"search": "ada", "password": "hunter2", "secret": "s3cr3t"}
+	if _, err := clt.Call("GET", "/users", nil, params); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("logged %q", messages)
+	}
+	if strings.Contains(messages[0], "hunter2") || strings.Contains(messages[0], "s3cr3t") {
+		t.Fatalf("logged a secret: %q", messages[0])
+	}
+	if !strings.HasSuffix(messages[0], "/v1/users?password=[REDACTED]&search=ada&secret=[REDACTED]") {
+		t.Fatalf("logged %q", messages[0])
+	}
+}
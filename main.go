@@ -1,8 +1,65 @@
 package appwrite
 
-// NewClient initializes a new Appwrite client
-func NewClient() Client {
-	return Client{
-		headers: make(map[string]string),
+import (
+	"sync"
+	"time"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*Client)
+
+// NewClient initializes a new Appwrite client connecting to DefaultEndpoint,
+// applying opts in order
+func NewClient(opts ...Option) Client {
+	clt := Client{
+		mu:            &sync.RWMutex{},
+		headers:       make(map[string]string),
+		http:          &httpState{},
+		cfg:           clientConfig{endpoint: DefaultEndpoint},
+		keys:          &keyRing{},
+		devKeyWarning: &sync.Once{},
+	}
+
+	for _, opt := range opts {
+		opt(&clt)
+	}
+
+	return clt
+}
+
+// WithEndpoint sets the endpoint the Client connects to. An invalid endpoint
+// is ignored, use SetEndpoint to get the validation error
+func WithEndpoint(endpoint string) Option {
+	return func(clt *Client) {
+		clt.SetEndpoint(endpoint)
+	}
+}
+
+// WithProject sets the project ID
+func WithProject(value string) Option {
+	return func(clt *Client) {
+		clt.SetProject(value)
+	}
+}
+
+// WithKey sets the secret API key
+func WithKey(value string) Option {
+	return func(clt *Client) {
+		clt.SetKey(value)
+	}
+}
+
+// WithSelfSigned allows connections to a server using a self-signed
+// certificate
+func WithSelfSigned(status bool) Option {
+	return func(clt *Client) {
+		clt.SetSelfSigned(status)
+	}
+}
+
+// WithTimeout sets the maximum duration the Client waits for a request
+func WithTimeout(timeout time.Duration) Option {
+	return func(clt *Client) {
+		clt.SetTimeout(timeout)
 	}
 }
@@ -0,0 +1,45 @@
+package appwrite
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewClientWithoutOptions(t *testing.T) {
+	clt := NewClient()
+	if clt.headers == nil {
+		t.Fatal("the headers map is nil")
+	}
+	clt.AddHeader("X-Test", "a")
+	if got := clt.header("X-Test"); got != "a" {
+		t.Fatalf("got header %q", got)
+	}
+	if got := clt.baseURL(); got != DefaultEndpoint {
+		t.Fatalf("got endpoint %q", got)
+	}
+}
+
+func TestNewClientAppliesOptionsInOrder(t *testing.T) {
+	clt := NewClient(
+		WithEndpoint("https://one.example.com/v1"),
+		WithProject("first"),
+		WithKey("key"),
+		WithSelfSigned(true),
+		WithTimeout(time.Second),
+		WithProject("second"),
+		WithEndpoint("https://two.example.com/v1"),
+	)
+
+	if got := clt.header("X-Appwrite-Project"); got != "second" {
+		t.Fatalf("got project %q", got)
+	}
+	if got := clt.header("X-Appwrite-Key"); got != "key" {
+		t.Fatalf("got key %q", got)
+	}
+	if got := clt.baseURL(); got != "https://two.example.com/v1" {
+		t.Fatalf("got endpoint %q", got)
+	}
+	if !clt.http.selfSigned || clt.http.timeout != time.Second {
+		t.Fatalf("got self-signed %v and timeout %v", clt.http.selfSigned, clt.http.timeout)
+	}
+}
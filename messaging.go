@@ -0,0 +1,110 @@
+package appwrite
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Messaging service
+type Messaging struct {
+	client Client
+}
+
+func NewMessaging(clt Client) Messaging {
+	service := Messaging{
+		client: clt,
+	}
+
+	return service
+}
+
+// CreateEmail create a new email message. The message is sent to the given
+// topics, users and targets, right away or at ScheduledAt when it is set to
+// an ISO 8601 date.
+func (srv *Messaging) CreateEmail(MessageId string, Subject string, Content string, Topics []string, Users []string, Targets []string, ScheduledAt string) (map[string]interface{}, error) {
+	path := "/messaging/messages/email"
+
+	params := map[string]interface{}{
+		"messageId": MessageId,
+		"subject":   Subject,
+		"content":   Content,
+		"topics":    Topics,
+		"users":     Users,
+		"targets":   Targets,
+	}
+	if ScheduledAt != "" {
+		params["scheduledAt"] = ScheduledAt
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// CreatePush create a new push notification. The notification is sent to the
+// given topics, users and targets, right away or at ScheduledAt when it is
+// set to an ISO 8601 date.
+func (srv *Messaging) CreatePush(MessageId string, Title string, Body string, Topics []string, Users []string, Targets []string, Data map[string]interface{}, ScheduledAt string) (map[string]interface{}, error) {
+	path := "/messaging/messages/push"
+
+	params := map[string]interface{}{
+		"messageId": MessageId,
+		"title":     Title,
+		"body":      Body,
+		"topics":    Topics,
+		"users":     Users,
+		"targets":   Targets,
+		"data":      Data,
+	}
+	if ScheduledAt != "" {
+		params["scheduledAt"] = ScheduledAt
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// CreateSms create a new SMS message. The message is sent to the given
+// topics, users and targets, right away or at ScheduledAt when it is set to
+// an ISO 8601 date.
+func (srv *Messaging) CreateSms(MessageId string, Content string, Topics []string, Users []string, Targets []string, ScheduledAt string) (map[string]interface{}, error) {
+	path := "/messaging/messages/sms"
+
+	params := map[string]interface{}{
+		"messageId": MessageId,
+		"content":   Content,
+		"topics":    Topics,
+		"users":     Users,
+		"targets":   Targets,
+	}
+	if ScheduledAt != "" {
+		params["scheduledAt"] = ScheduledAt
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// CreateTopic create a new topic. Subscribe lists the roles allowed to
+// subscribe to the topic.
+func (srv *Messaging) CreateTopic(TopicId string, Name string, Subscribe []string) (map[string]interface{}, error) {
+	path := "/messaging/topics"
+
+	params := map[string]interface{}{
+		"topicId":   TopicId,
+		"name":      Name,
+		"subscribe": Subscribe,
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// CreateSubscriber create a new subscriber, subscribing a messaging target
+// to a topic.
+func (srv *Messaging) CreateSubscriber(TopicId string, SubscriberId string, TargetId string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{topicId}", url.PathEscape(TopicId))
+	path := r.Replace("/messaging/topics/{topicId}/subscribers")
+
+	params := map[string]interface{}{
+		"subscriberId": SubscriberId,
+		"targetId":     TargetId,
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
@@ -0,0 +1,58 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// recordBody starts a test server decoding the JSON body of each request
+// into the returned map
+func recordBody(t *testing.T) (Client, map[string]interface{}) {
+	t.Helper()
+
+	body := map[string]interface{}{}
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		body["$path"] = r.URL.Path
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+	return clt, body
+}
+
+func TestMessagingCreateEmail(t *testing.T) {
+	clt, body := recordBody(t)
+	srv := NewMessaging(clt)
+
+	if _, err := srv.CreateEmail("m1", "Hi", "Hello", []string{"t1"}, nil, []string{"a", "b"}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if body["$path"] != "/v1/messaging/messages/email" {
+		t.Fatalf("sent to %v", body["$path"])
+	}
+	if body["subject"] != "Hi" || body["content"] != "Hello" {
+		t.Fatalf("got %v", body)
+	}
+	if !reflect.DeepEqual(body["targets"], []interface{}{"a", "b"}) || !reflect.DeepEqual(body["topics"], []interface{}{"t1"}) {
+		t.Fatalf("got targets %v and topics %v", body["targets"], body["topics"])
+	}
+	if _, ok := body["scheduledAt"]; ok {
+		t.Fatalf("sent an empty scheduledAt")
+	}
+}
+
+func TestMessagingScheduledAt(t *testing.T) {
+	clt, body := recordBody(t)
+	srv := NewMessaging(clt)
+
+	if _, err := srv.CreateSms("m1", "Hello", nil, []string{"u1"}, nil, "2030-01-01T00:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	if body["scheduledAt"] != "2030-01-01T00:00:00Z" {
+		t.Fatalf("got scheduledAt %v", body["scheduledAt"])
+	}
+}
@@ -0,0 +1,58 @@
+package appwrite
+
+import (
+	"context"
+	"time"
+)
+
+// CallOptions holds the per-call settings of CallWithOptions
+type CallOptions struct {
+	// Context bounds the call, defaulting to context.Background()
+	Context context.Context
+
+	// Headers are sent with this call only, over the client headers, and
+	// never stored on the Client
+	Headers map[string]string
+
+	// Timestamp, when set, is sent in the X-Appwrite-Timestamp header so the
+	// server rejects the update with a 409 conflict if the resource changed
+	// after that time
+	Timestamp time.Time
+
+	// IdempotencyKey, when set, is sent in the X-Appwrite-Idempotency-Key
+	// header and allows the call to be retried whatever its method, see
+	// SetRetry
+	IdempotencyKey string
+
+	// Form sends the params as an application/x-www-form-urlencoded body
+	// instead of JSON, for the endpoints expecting one
+	Form bool
+}
+
+// timestampFormat is the layout of the X-Appwrite-Timestamp header
+const timestampFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// CallWithOptions calls an API using Client with per-call options, leaving
+// the Client configuration untouched
+func (clt *Client) CallWithOptions(method string, path string, params map[string]interface{}, options CallOptions) (*Response, error) {
+	ctx := options.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	headers := make(map[string]interface{}, len(options.Headers)+3)
+	for key, val := range options.Headers {
+		headers[key] = val
+	}
+	if options.IdempotencyKey != "" {
+		headers[IdempotencyKeyHeader] = options.IdempotencyKey
+	}
+	if options.Form {
+		headers["Content-Type"] = formContentType
+	}
+	if !options.Timestamp.IsZero() {
+		headers["X-Appwrite-Timestamp"] = options.Timestamp.UTC().Format(timestampFormat)
+	}
+
+	return clt.CallWithResponse(ctx, method, path, headers, params)
+}
@@ -0,0 +1,78 @@
+package appwrite
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCallWithOptionsHeadersDoNotPersist(t *testing.T) {
+	clt, headers := recordHeaders(t)
+
+	options := CallOptions{Headers: map[string]string{"X-Fallback-Cookies": "a=b"}}
+	if _, err := clt.CallWithOptions("GET", "/health", nil, options); err != nil {
+		t.Fatal(err)
+	}
+	if got := headers().Get("X-Fallback-Cookies"); got != "a=b" {
+		t.Fatalf("sent per-call header %q", got)
+	}
+
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := headers().Get("X-Fallback-Cookies"); got != "" {
+		t.Fatalf("per-call header leaked into the next call: %q", got)
+	}
+	if got := clt.header("X-Fallback-Cookies"); got != "" {
+		t.Fatalf("per-call header stored on the client: %q", got)
+	}
+}
+
+func TestCallWithOptionsOverridesClientHeaders(t *testing.T) {
+	clt, headers := recordHeaders(t)
+	clt.AddHeader("X-Custom", "client")
+
+	options := CallOptions{Headers: map[string]string{"X-Custom": "call"}}
+	if _, err := clt.CallWithOptions("GET", "/health", nil, options); err != nil {
+		t.Fatal(err)
+	}
+	if got := headers().Get("X-Custom"); got != "call" {
+		t.Fatalf("sent header %q", got)
+	}
+	if got := clt.header("X-Custom"); got != "client" {
+		t.Fatalf("client header changed to %q", got)
+	}
+}
+
+func TestCallWithOptionsForm(t *testing.T) {
+	var body, contentType string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body, contentType = string(data), r.Header.Get("Content-Type")
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	params := map[string]interface{}{
+		"active": true,
+		"tags":   []string{"go", "sdk"},
+		"name":   "Ada Lovelace",
+		"cursor": nil,
+	}
+	if _, err := clt.CallWithOptions("POST", "/legacy", params, CallOptions{Form: true}); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "application/x-www-form-urlencoded" {
+		t.Fatalf("sent content type %q", contentType)
+	}
+	if body != "active=true&name=Ada+Lovelace&tags%5B%5D=go&tags%5B%5D=sdk" {
+		t.Fatalf("sent body %q", body)
+	}
+
+	headers := map[string]interface{}{"content-type": "application/x-www-form-urlencoded; charset=utf-8"}
+	if _, err := clt.Call("POST", "/legacy", headers, map[string]interface{}{"count": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if body != "count=1" {
+		t.Fatalf("sent body %q", body)
+	}
+}
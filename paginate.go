@@ -0,0 +1,89 @@
+package appwrite
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListFunc fetches a single page of a list endpoint, passing queries along
+// as the "queries" param
+type ListFunc func(ctx context.Context, queries []string) (map[string]interface{}, error)
+
+// listKeys are the keys holding the items of list responses, looked for by
+// Paginate
+var listKeys = []string{"documents", "files", "users", "memberships", "teams", "executions", "sessions", "buckets", "collections", "databases", "functions"}
+
+// Paginate collects the items of every page returned by list, found under
+// the first of the usual list keys present in the first page, such as
+// documents, files, users or memberships. Pages hold up to pageSize items
+// and are requested with a cursor after the $id of the last item seen,
+// until a page comes back short
+func Paginate(ctx context.Context, pageSize int, list ListFunc) ([]map[string]interface{}, error) {
+	return PaginateKey(ctx, pageSize, "", list)
+}
+
+// PaginateKey is Paginate reading the items of each page under key. An
+// empty key looks for the usual list keys
+func PaginateKey(ctx context.Context, pageSize int, key string, list ListFunc) ([]map[string]interface{}, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("invalid page size %d", pageSize)
+	}
+
+	var items []map[string]interface{}
+	cursor := ""
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return items, err
+		}
+
+		queries := []string{Query{}.Limit(pageSize)}
+		if cursor != "" {
+			queries = append(queries, Query{}.CursorAfter(cursor))
+		}
+
+		page, err := list(ctx, queries)
+		if err != nil {
+			return items, err
+		}
+
+		if key == "" {
+			if key = detectListKey(page); key == "" {
+				return items, fmt.Errorf("list response has none of the keys %v", listKeys)
+			}
+		}
+
+		if _, ok := page[key]; !ok {
+			return items, fmt.Errorf("list response has no %q key", key)
+		}
+
+		pageItems, _ := page[key].([]interface{})
+		for _, pageItem := range pageItems {
+			item, ok := pageItem.(map[string]interface{})
+			if !ok {
+				return items, fmt.Errorf("unexpected list item of type %T", pageItem)
+			}
+			items = append(items, item)
+		}
+
+		if len(pageItems) < pageSize {
+			return items, nil
+		}
+
+		id, ok := DocumentID(items[len(items)-1])
+		if !ok {
+			return items, fmt.Errorf("list item has no $id to continue from")
+		}
+		cursor = id
+	}
+}
+
+// detectListKey returns the first of listKeys present in page, if any
+func detectListKey(page map[string]interface{}) string {
+	for _, key := range listKeys {
+		if _, ok := page[key]; ok {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// newListServer starts a server listing count documents, d1 to d<count>,
+// honoring the limit and cursorAfter queries
+func newListServer(t *testing.T, key string, count int) (Client, *int) {
+	t.Helper()
+
+	requests := 0
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		limit, start := count, 0
+		for _, query := range r.URL.Query()["queries[]"] {
+			var q struct {
+				Method string        `json:"method"`
+				Values []interface{} `json:"values"`
+			}
+			if err := json.Unmarshal([]byte(query), &q); err != nil {
+				t.Errorf("invalid query %q", query)
+			}
+			switch q.Method {
+			case "limit":
+				limit = int(q.Values[0].(float64))
+			case "cursorAfter":
+				fmt.Sscanf(q.Values[0].(string), "d%d", &start)
+			}
+		}
+
+		var items []map[string]interface{}
+		for i := start + 1; i <= count && len(items) < limit; i++ {
+			items = append(items, map[string]interface{}{"$id": fmt.Sprint("d", i)})
+		}
+		body, _ := json.Marshal(map[string]interface{}{"total": count, key: items})
+		writeJSON(w, http.StatusOK, string(body))
+	})
+	return clt, &requests
+}
+
+// listDocuments returns a ListFunc listing the documents of clt
+func listDocuments(clt Client) ListFunc {
+	srv := NewDatabases(clt)
+	return func(ctx context.Context, queries []string) (map[string]interface{}, error) {
+		return srv.ListDocuments("db", "c", queries)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	clt, requests := newListServer(t, "documents", 7)
+
+	items, err := Paginate(context.Background(), 3, listDocuments(clt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 3 || len(items) != 7 {
+		t.Fatalf("got %d items in %d requests", len(items), *requests)
+	}
+	seen := map[string]bool{}
+	for i, item := range items {
+		id := item["$id"].(string)
+		if seen[id] || id != fmt.Sprint("d", i+1) {
+			t.Fatalf("got %v", items)
+		}
+		seen[id] = true
+	}
+}
+
+func TestPaginateCancelled(t *testing.T) {
+	clt, requests := newListServer(t, "documents", 7)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	list := listDocuments(clt)
+	_, err := Paginate(ctx, 3, func(ctx context.Context, queries []string) (map[string]interface{}, error) {
+		defer cancel()
+		return list(ctx, queries)
+	})
+	if !errors.Is(err, context.Canceled) || *requests != 1 {
+		t.Fatalf("got %v after %d requests", err, *requests)
+	}
+}
+
+func TestPaginateInvalidPageSize(t *testing.T) {
+	if _, err := Paginate(context.Background(), 0, nil); err == nil {
+		t.Fatal("accepted a page size of 0")
+	}
+}
+
+func TestPaginateMemberships(t *testing.T) {
+	clt, requests := newListServer(t, "memberships", 3)
+	list := func(ctx context.Context, queries []string) (map[string]interface{}, error) {
+		return clt.CallWithContext(ctx, "GET", "/teams/t1/memberships", nil, map[string]interface{}{"queries": queries})
+	}
+
+	for _, key := range []string{"", "memberships"} {
+		*requests = 0
+		items, err := PaginateKey(context.Background(), 2, key, list)
+		if err != nil {
+			t.Fatalf("key %q: %v", key, err)
+		}
+		if *requests != 2 || len(items) != 3 || items[2]["$id"] != "d3" {
+			t.Fatalf("key %q: got %v in %d requests", key, items, *requests)
+		}
+	}
+
+	if _, err := PaginateKey(context.Background(), 2, "documents", list); err == nil {
+		t.Fatal("expected an error for a missing list key")
+	}
+}
@@ -0,0 +1,30 @@
+package appwrite
+
+// Permission builds the permission strings accepted by the "permissions"
+// param of create and update endpoints
+type Permission struct{}
+
+// Read allows role to read the resource
+func (p Permission) Read(role string) string {
+	return "read(\"" + role + "\")"
+}
+
+// Write allows role to create, update and delete the resource
+func (p Permission) Write(role string) string {
+	return "write(\"" + role + "\")"
+}
+
+// Create allows role to create resources
+func (p Permission) Create(role string) string {
+	return "create(\"" + role + "\")"
+}
+
+// Update allows role to update the resource
+func (p Permission) Update(role string) string {
+	return "update(\"" + role + "\")"
+}
+
+// Delete allows role to delete the resource
+func (p Permission) Delete(role string) string {
+	return "delete(\"" + role + "\")"
+}
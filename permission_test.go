@@ -0,0 +1,24 @@
+package appwrite
+
+import (
+	"testing"
+)
+
+func TestPermission(t *testing.T) {
+	p, r := Permission{}, Role{}
+	tests := []struct {
+		got  string
+		want string
+	}{
+		{p.Read(r.Any()), `read("any")`},
+		{p.Write(r.User("123")), `write("user:123")`},
+		{p.Create(r.Users()), `create("users")`},
+		{p.Update(r.Team("t1", "owner")), `update("team:t1/owner")`},
+		{p.Delete(r.Guests()), `delete("guests")`},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got %s, want %s", test.got, test.want)
+		}
+	}
+}
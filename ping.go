@@ -0,0 +1,31 @@
+package appwrite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrInvalidCredentials is returned by Ping when the server rejects the
+// project or credentials of the Client
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Ping checks that the server is reachable and accepts the project and
+// credentials of the Client, as a startup check before doing real work. A
+// 401 response is returned as an error wrapping ErrInvalidCredentials
+func (clt *Client) Ping() error {
+	return clt.PingWithContext(context.Background())
+}
+
+// PingWithContext is Ping aborting the request when ctx is cancelled or its
+// deadline expires
+func (clt *Client) PingWithContext(ctx context.Context) error {
+	_, err := clt.CallWithContext(ctx, "GET", "/health", nil, nil)
+
+	var exception *AppwriteException
+	if errors.As(err, &exception) && exception.Code == http.StatusUnauthorized {
+		return fmt.Errorf("%w: %s", ErrInvalidCredentials, exception.Message)
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package appwrite
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/health" {
+			t.Errorf("got path %q", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, `{"status":"pass"}`)
+	})
+
+	if err := clt.Ping(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPingInvalidCredentials(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusUnauthorized, `{"message":"Invalid API key","code":401,"type":"user_unauthorized"}`)
+	})
+
+	err := clt.Ping()
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("got %v", err)
+	}
+	if got, want := err.Error(), "invalid credentials: Invalid API key"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestPingNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	clt := NewClient(WithProject("test"), WithEndpoint(server.URL))
+
+	err := clt.Ping()
+	if err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	if errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("a network error was reported as invalid credentials: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package appwrite
+
+import (
+	"encoding/json"
+)
+
+// Query builds the query strings accepted by the "queries" param of list
+// endpoints
+type Query struct{}
+
+type queryOptions struct {
+	Method    string        `json:"method"`
+	Attribute string        `json:"attribute,omitempty"`
+	Values    []interface{} `json:"values,omitempty"`
+}
+
+func buildQuery(method string, attribute string, values []interface{}) string {
+	data, err := json.Marshal(queryOptions{
+		Method:    method,
+		Attribute: attribute,
+		Values:    values,
+	})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// Equal filters resources where attribute is equal to any of values
+func (q Query) Equal(attribute string, values ...interface{}) string {
+	return buildQuery("equal", attribute, values)
+}
+
+// NotEqual filters resources where attribute is not equal to any of values
+func (q Query) NotEqual(attribute string, values ...interface{}) string {
+	return buildQuery("notEqual", attribute, values)
+}
+
+// LessThan filters resources where attribute is less than value
+func (q Query) LessThan(attribute string, value interface{}) string {
+	return buildQuery("lessThan", attribute, []interface{}{value})
+}
+
+// LessThanEqual filters resources where attribute is less than or equal to
+// value
+func (q Query) LessThanEqual(attribute string, value interface{}) string {
+	return buildQuery("lessThanEqual", attribute, []interface{}{value})
+}
+
+// GreaterThan filters resources where attribute is greater than value
+func (q Query) GreaterThan(attribute string, value interface{}) string {
+	return buildQuery("greaterThan", attribute, []interface{}{value})
+}
+
+// GreaterThanEqual filters resources where attribute is greater than or
+// equal to value
+func (q Query) GreaterThanEqual(attribute string, value interface{}) string {
+	return buildQuery("greaterThanEqual", attribute, []interface{}{value})
+}
+
+// Search filters resources where attribute matches the full-text search
+// value
+func (q Query) Search(attribute string, value string) string {
+	return buildQuery("search", attribute, []interface{}{value})
+}
+
+// Contains filters resources where the array attribute contains any of
+// values, or the string attribute contains any of them as a substring
+func (q Query) Contains(attribute string, values ...interface{}) string {
+	return buildQuery("contains", attribute, values)
+}
+
+// IsNull filters resources where attribute is null
+func (q Query) IsNull(attribute string) string {
+	return buildQuery("isNull", attribute, nil)
+}
+
+// IsNotNull filters resources where attribute is not null
+func (q Query) IsNotNull(attribute string) string {
+	return buildQuery("isNotNull", attribute, nil)
+}
+
+// StartsWith filters resources where attribute starts with value
+func (q Query) StartsWith(attribute string, value string) string {
+	return buildQuery("startsWith", attribute, []interface{}{value})
+}
+
+// EndsWith filters resources where attribute ends with value
+func (q Query) EndsWith(attribute string, value string) string {
+	return buildQuery("endsWith", attribute, []interface{}{value})
+}
+
+// Between filters resources where attribute is between start and end,
+// both included
+func (q Query) Between(attribute string, start interface{}, end interface{}) string {
+	return buildQuery("between", attribute, []interface{}{start, end})
+}
+
+// Select returns only the given attributes of each resource
+func (q Query) Select(attributes ...string) string {
+	values := make([]interface{}, len(attributes))
+	for i, attribute := range attributes {
+		values[i] = attribute
+	}
+	return buildQuery("select", "", values)
+}
+
+// OrderAsc sorts results by attribute in ascending order
+func (q Query) OrderAsc(attribute string) string {
+	return buildQuery("orderAsc", attribute, nil)
+}
+
+// OrderDesc sorts results by attribute in descending order
+func (q Query) OrderDesc(attribute string) string {
+	return buildQuery("orderDesc", attribute, nil)
+}
+
+// Limit returns at most limit results
+func (q Query) Limit(limit int) string {
+	return buildQuery("limit", "", []interface{}{limit})
+}
+
+// Offset skips the first offset results
+func (q Query) Offset(offset int) string {
+	return buildQuery("offset", "", []interface{}{offset})
+}
+
+// CursorAfter returns results after the resource with the given id
+func (q Query) CursorAfter(id string) string {
+	return buildQuery("cursorAfter", "", []interface{}{id})
+}
+
+// CursorBefore returns results before the resource with the given id
+func (q Query) CursorBefore(id string) string {
+	return buildQuery("cursorBefore", "", []interface{}{id})
+}
+
+// DocumentID returns the $id of a resource returned by the API, for use with
+// CursorAfter and CursorBefore
+func DocumentID(document map[string]interface{}) (string, bool) {
+	id, ok := document["$id"].(string)
+	return id, ok && id != ""
+}
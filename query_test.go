@@ -0,0 +1,75 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	q := Query{}
+	tests := []struct {
+		got  string
+		want string
+	}{
+		{q.Equal("status", "active"), `{"method":"equal","attribute":"status","values":["active"]}`},
+		{q.Equal("status", "active", "pending", 3), `{"method":"equal","attribute":"status","values":["active","pending",3]}`},
+		{q.Equal("title", `say "hi"`), `{"method":"equal","attribute":"title","values":["say \"hi\""]}`},
+		{q.NotEqual("status", "deleted"), `{"method":"notEqual","attribute":"status","values":["deleted"]}`},
+		{q.LessThan("age", 18), `{"method":"lessThan","attribute":"age","values":[18]}`},
+		{q.LessThanEqual("age", 18.5), `{"method":"lessThanEqual","attribute":"age","values":[18.5]}`},
+		{q.GreaterThan("age", 65), `{"method":"greaterThan","attribute":"age","values":[65]}`},
+		{q.GreaterThanEqual("age", 65), `{"method":"greaterThanEqual","attribute":"age","values":[65]}`},
+		{q.Search("body", "go sdk"), `{"method":"search","attribute":"body","values":["go sdk"]}`},
+		{q.Contains("tags", "go"), `{"method":"contains","attribute":"tags","values":["go"]}`},
+		{q.Contains("tags", "go", "sdk"), `{"method":"contains","attribute":"tags","values":["go","sdk"]}`},
+		{q.IsNull("deletedAt"), `{"method":"isNull","attribute":"deletedAt"}`},
+		{q.IsNotNull("deletedAt"), `{"method":"isNotNull","attribute":"deletedAt"}`},
+		{q.StartsWith("name", "Ad"), `{"method":"startsWith","attribute":"name","values":["Ad"]}`},
+		{q.EndsWith("email", "@appwrite.io"), `{"method":"endsWith","attribute":"email","values":["@appwrite.io"]}`},
+		{q.Between("age", 18, 65.5), `{"method":"between","attribute":"age","values":[18,65.5]}`},
+		{q.Between("name", "a", "m"), `{"method":"between","attribute":"name","values":["a","m"]}`},
+		{q.OrderAsc("name"), `{"method":"orderAsc","attribute":"name"}`},
+		{q.OrderDesc("$createdAt"), `{"method":"orderDesc","attribute":"$createdAt"}`},
+		{q.Select("name", "email", `say "hi"`), `{"method":"select","values":["name","email","say \"hi\""]}`},
+		{q.Limit(25), `{"method":"limit","values":[25]}`},
+		{q.Offset(50), `{"method":"offset","values":[50]}`},
+		{q.CursorAfter("doc1"), `{"method":"cursorAfter","values":["doc1"]}`},
+		{q.CursorBefore("doc1"), `{"method":"cursorBefore","values":["doc1"]}`},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got %s, want %s", test.got, test.want)
+		}
+	}
+}
+
+func TestDocumentID(t *testing.T) {
+	if id, ok := DocumentID(map[string]interface{}{"$id": "doc1"}); id != "doc1" || !ok {
+		t.Fatalf("got %q, %v", id, ok)
+	}
+	if _, ok := DocumentID(map[string]interface{}{"$id": 1}); ok {
+		t.Fatal("accepted a non-string id")
+	}
+	if _, ok := DocumentID(map[string]interface{}{"title": "no id"}); ok {
+		t.Fatal("accepted a document without an id")
+	}
+}
+
+func TestCursorAfterLastDocument(t *testing.T) {
+	var list struct {
+		Documents []map[string]interface{} `json:"documents"`
+	}
+	response := `{"total":2,"documents":[{"$id":"doc1","title":"a"},{"$id":"doc2","title":"b"}]}`
+	if err := json.Unmarshal([]byte(response), &list); err != nil {
+		t.Fatal(err)
+	}
+
+	id, ok := DocumentID(list.Documents[len(list.Documents)-1])
+	if !ok {
+		t.Fatal("no id found in the last document")
+	}
+	want := `{"method":"cursorAfter","values":["doc2"]}`
+	if got := (Query{}).CursorAfter(id); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
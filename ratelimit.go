@@ -0,0 +1,81 @@
+package appwrite
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRateLimitDelay is how long SetRateLimitWait waits on a 429 response
+// without a Retry-After header
+const defaultRateLimitDelay = time.Second
+
+// minRateLimitDelay is the shortest wait on a 429 response, so that a
+// Retry-After of zero or in the past doesn't resend in a tight loop
+const minRateLimitDelay = 50 * time.Millisecond
+
+// RateLimit holds the rate limit state reported by the server for the
+// endpoint of a call. Fields are left zero when the matching header is
+// missing
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// parseRateLimit reads the X-RateLimit-* headers of a response
+func parseRateLimit(headers http.Header) RateLimit {
+	var rateLimit RateLimit
+
+	if limit, err := strconv.Atoi(headers.Get("X-RateLimit-Limit")); err == nil {
+		rateLimit.Limit = limit
+	}
+	if remaining, err := strconv.Atoi(headers.Get("X-RateLimit-Remaining")); err == nil {
+		rateLimit.Remaining = remaining
+	}
+	if reset, err := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.Reset = time.Unix(reset, 0)
+	}
+
+	return rateLimit
+}
+
+// SetRateLimitWait makes the Client wait out rate limits: a request answered
+// with 429 is sent again after the Retry-After delay, for any method since
+// the server rejected it without handling it. Waiting stops once the delays
+// of a call would add up to more than maxWait, or would outlast the deadline
+// of its context, and the 429 error is returned. This is independent from
+// SetRetry, and a zero maxWait disables it
+func (clt *Client) SetRateLimitWait(maxWait time.Duration) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.rateLimitWait = maxWait
+	})
+}
+
+// rateLimitDelay returns how long to wait before sending a rate limited
+// request again, given how long the call already waited, and whether the
+// request should be sent again at all
+func (clt *Client) rateLimitDelay(ctx context.Context, response *http.Response, waited time.Duration) (time.Duration, bool) {
+	maxWait := clt.config().rateLimitWait
+	if maxWait <= 0 {
+		return 0, false
+	}
+
+	delay, ok := parseRetryAfter(response.Header.Get("Retry-After"))
+	if !ok {
+		delay = defaultRateLimitDelay
+	}
+	if delay < minRateLimitDelay {
+		delay = minRateLimitDelay
+	}
+
+	if waited+delay > maxWait {
+		return 0, false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return 0, false
+	}
+
+	return delay, true
+}
@@ -0,0 +1,135 @@
+package appwrite
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseRateLimit(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	response, err := clt.CallWithResponse(context.Background(), "GET", "/health", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RateLimit{Limit: 60, Remaining: 59, Reset: time.Unix(1700000000, 0)}
+	if response.RateLimit != want {
+		t.Fatalf("got %+v", response.RateLimit)
+	}
+}
+
+func TestParseRateLimitMissingHeaders(t *testing.T) {
+	if got := parseRateLimit(http.Header{}); got != (RateLimit{}) {
+		t.Fatalf("got %+v", got)
+	}
+
+	headers := http.Header{"X-Ratelimit-Limit": {"many"}, "X-Ratelimit-Remaining": {"3"}}
+	if got := parseRateLimit(headers); got != (RateLimit{Remaining: 3}) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestRateLimitWaitRetriesAfterRetryAfter(t *testing.T) {
+	var calls int32
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0.05")
+			writeJSON(w, http.StatusTooManyRequests, `{"message":"rate limited","code":429}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"ok":true}`)
+	})
+	clt.SetRateLimitWait(time.Second)
+
+	start := time.Now()
+	result, err := clt.Call("GET", "/health", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["ok"] != true || atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("got %v after %d calls", result, calls)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("waited %v", elapsed)
+	}
+}
+
+func TestRateLimitWaitStopsAtDeadline(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		writeJSON(w, http.StatusTooManyRequests, `{"message":"rate limited","code":429}`)
+	})
+	clt.SetRateLimitWait(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := clt.CallWithContext(ctx, "GET", "/health", nil, nil)
+	if err == nil {
+		t.Fatal("expected the 429 error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("slept past the deadline: %v", elapsed)
+	}
+}
+
+func TestRateLimitWaitZeroRetryAfter(t *testing.T) {
+	var calls int32
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "0")
+		writeJSON(w, http.StatusTooManyRequests, `{"message":"rate limited","code":429}`)
+	})
+	clt.SetRateLimitWait(100 * time.Millisecond)
+
+	if _, err := clt.Call("GET", "/health", nil, nil); err == nil {
+		t.Fatal("expected the 429 error")
+	}
+	if got := atomic.LoadInt32(&calls); got > 3 {
+		t.Fatalf("sent %d requests within a 100ms wait", got)
+	}
+}
+
+func TestRateLimitWaitResendsFiles(t *testing.T) {
+	content := strings.Repeat("x", 1024)
+	var calls int32
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != content {
+			t.Errorf("attempt %d got %d bytes", atomic.LoadInt32(&calls)+1, len(data))
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			writeJSON(w, http.StatusTooManyRequests, `{"message":"rate limited","code":429}`)
+			return
+		}
+		writeJSON(w, http.StatusCreated, `{}`)
+	})
+	clt.SetRateLimitWait(time.Second)
+
+	// A reader that can't seek is buffered once for both attempts
+	reader := io.MultiReader(bytes.NewReader([]byte(content)))
+	params := map[string]interface{}{"file": NewInputFile("a.txt", reader)}
+	if _, err := clt.Call("POST", "/storage/buckets/b/files", nil, params); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("got %d calls", calls)
+	}
+}
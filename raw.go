@@ -0,0 +1,126 @@
+package appwrite
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// CallRaw calls an API using Client, sending body verbatim with the given
+// Content-Type instead of a JSON object of params. A body implementing
+// io.Seeker is rewound when the call is retried, any other body is read
+// into memory first so it can be resent
+func (clt *Client) CallRaw(method string, path string, headers map[string]string, body io.Reader, contentType string) (*Response, error) {
+	return clt.CallRawWithContext(context.Background(), method, path, headers, body, contentType)
+}
+
+// CallRawWithContext is CallRaw aborting the request when ctx is cancelled
+// or its deadline expires
+func (clt *Client) CallRawWithContext(ctx context.Context, method string, path string, headers map[string]string, body io.Reader, contentType string) (*Response, error) {
+	method, err := normalizeMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		body = http.NoBody
+	}
+	seeker, ok := body.(io.Seeker)
+	if !ok {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		reader := bytes.NewReader(data)
+		body, seeker = reader, reader
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// rewind returns body read from its start. It is wrapped so that the
+	// transport doesn't close a body owned by the caller, such as a file
+	rewind := func() (io.ReadCloser, error) {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(body), nil
+	}
+
+	callHeaders := make(map[string]interface{}, len(headers))
+	for key, val := range headers {
+		callHeaders[key] = val
+	}
+
+	clt.ensureClientInitialized()
+
+	response, err := clt.do(ctx, method, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, joinURL(clt.baseURL(), path), http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		if end > start {
+			if req.Body, err = rewind(); err != nil {
+				return nil, err
+			}
+			req.ContentLength = end - start
+			req.GetBody = rewind
+		}
+		if err := clt.setRequestHeaders(ctx, req, callHeaders); err != nil {
+			return nil, err
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		clt.logRequest(req, nil)
+
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.readResponse(response)
+}
+
+// DoRequest sends a request built by the caller with the Client's
+// *http.Client, honoring its timeout and self-signed settings. The default
+// and client headers are added to req, the headers already set on req
+// taking precedence, and a request URL without a host is resolved against
+// the endpoint. Nothing else is done: the request is not retried and the
+// response is returned as is, without checking its status or decoding its
+// body, which the caller must close
+func (clt *Client) DoRequest(req *http.Request) (*http.Response, error) {
+	if !req.URL.IsAbs() {
+		resolved, err := url.Parse(joinURL(clt.baseURL(), req.URL.EscapedPath()))
+		if err != nil {
+			return nil, err
+		}
+		resolved.RawQuery = req.URL.RawQuery
+		req.URL = resolved
+		req.Host = resolved.Host
+	}
+
+	callerHeaders := req.Header.Clone()
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	if err := clt.setRequestHeaders(req.Context(), req, nil); err != nil {
+		return nil, err
+	}
+	for key, values := range callerHeaders {
+		req.Header[key] = values
+	}
+
+	clt.ensureClientInitialized()
+
+	return clt.httpClient().Do(req)
+}
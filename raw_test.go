@@ -0,0 +1,125 @@
+package appwrite
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rawRequest is a request received by the server of TestCallRaw
+type rawRequest struct {
+	body          string
+	contentType   string
+	contentLength int64
+	chunked       bool
+}
+
+func TestCallRaw(t *testing.T) {
+	var received []rawRequest
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, rawRequest{
+			body:          string(body),
+			contentType:   r.Header.Get("Content-Type"),
+			contentLength: r.ContentLength,
+			chunked:       len(r.TransferEncoding) > 0,
+		})
+		if len(received) == 1 {
+			writeJSON(w, http.StatusServiceUnavailable, `{"message":"unavailable","code":503}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"ok":true}`)
+	})
+	clt.SetRetry(2, time.Millisecond)
+
+	var event DebugEvent
+	clt.SetDebugLogger(func(e DebugEvent) { event = e })
+
+	response, err := clt.CallRaw("PUT", "/functions/f/raw", nil, strings.NewReader("hello raw"), "text/plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Body["ok"] != true || len(received) != 2 {
+		t.Fatalf("got %v after %d requests", response.Body, len(received))
+	}
+	for i, req := range received {
+		if req.body != "hello raw" || req.contentType != "text/plain" {
+			t.Fatalf("request %d sent %q as %q", i, req.body, req.contentType)
+		}
+		if req.contentLength != 9 || req.chunked {
+			t.Fatalf("request %d sent a length of %d, chunked %v", i, req.contentLength, req.chunked)
+		}
+	}
+	if event.RequestBody != "[text/plain body omitted]" {
+		t.Fatalf("debug event holds request body %q", event.RequestBody)
+	}
+}
+
+func TestCallRawBuffersReaders(t *testing.T) {
+	var body string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	reader := io.LimitReader(strings.NewReader("hello raw"), 5)
+	if _, err := clt.CallRaw("POST", "/functions/f/raw", nil, reader, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	if body != "hello" {
+		t.Fatalf("sent %q", body)
+	}
+}
+
+func TestDoRequest(t *testing.T) {
+	var project, custom, userAgent, uri string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		project, custom = r.Header.Get("X-Appwrite-Project"), r.Header.Get("X-Custom")
+		userAgent, uri = r.Header.Get("User-Agent"), r.URL.RequestURI()
+		writeJSON(w, http.StatusNotFound, `{"raw":true}`)
+	})
+	clt.AddHeader("X-Custom", "client")
+
+	req, err := http.NewRequest("GET", "/health?verbose=1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("User-Agent", "caller")
+	response, err := clt.DoRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusNotFound || string(body) != `{"raw":true}` {
+		t.Fatalf("got %d %q", response.StatusCode, body)
+	}
+	if project != "test" || userAgent != "caller" || uri != "/v1/health?verbose=1" {
+		t.Fatalf("sent project %q, user agent %q to %q", project, userAgent, uri)
+	}
+	if custom != "client" {
+		t.Fatalf("sent client header %q", custom)
+	}
+}
+
+func TestDoRequestKeepsEscapedPath(t *testing.T) {
+	clt, request := recordRequests(t, `{}`)
+
+	req, err := http.NewRequest("GET", "/storage/files/a%2Fb", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := clt.DoRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if got := request().Path; got != "/v1/storage/files/a%2Fb" {
+		t.Fatalf("sent path %q", got)
+	}
+}
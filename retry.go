@@ -0,0 +1,96 @@
+package appwrite
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetRetry sets how many times in total the Client attempts an idempotent
+// request that was rate limited (429) or hit an unavailable server (503).
+// The Retry-After header is honored when present, otherwise the delay grows
+// exponentially from baseDelay. A maxAttempts of 1 or less disables retries.
+//
+// Only GET, HEAD, OPTIONS, PUT and DELETE requests are retried by default: a
+// POST usually creates a resource, and resending one the server handled
+// before failing would create a duplicate. A POST or PATCH is retried once
+// it carries an idempotency key in its IdempotencyKeyHeader, for instance
+// set with CallOptions.IdempotencyKey, letting the server drop the
+// duplicates
+func (clt *Client) SetRetry(maxAttempts int, baseDelay time.Duration) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.retryAttempts = maxAttempts
+		cfg.retryDelay = baseDelay
+	})
+}
+
+// isIdempotent reports whether a request with the given method can be sent
+// again without side effects
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+// canRetry reports whether req may be sent more than once
+func canRetry(req *http.Request) bool {
+	return isIdempotent(req.Method) || req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// retryDelay returns how long to wait before the next attempt, preferring the
+// server provided Retry-After value over exponential backoff
+func retryDelay(response *http.Response, baseDelay time.Duration, attempt int) time.Duration {
+	if delay, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+		return delay
+	}
+	return baseDelay << (attempt - 1)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until ctx is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drainBody discards what is left of a response body so the underlying
+// connection can be reused
+func drainBody(body io.ReadCloser) {
+	io.Copy(io.Discard, body)
+	body.Close()
+}
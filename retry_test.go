@@ -0,0 +1,146 @@
+package appwrite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingServer starts a server answering the first failures requests with
+// status, and the following ones with an empty JSON object. It returns the
+// number of requests received so far
+func failingServer(t *testing.T, failures int32, status int, retryAfter string) (Client, func() int32) {
+	t.Helper()
+
+	var calls int32
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			writeJSON(w, status, `{"message":"failed","code":0}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"ok":true}`)
+	})
+	return clt, func() int32 { return atomic.LoadInt32(&calls) }
+}
+
+func TestSetRetryRetriesRateLimits(t *testing.T) {
+	clt, calls := failingServer(t, 2, http.StatusTooManyRequests, "0")
+	clt.SetRetry(3, time.Hour)
+
+	result, err := clt.Call("GET", "/health", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["ok"] != true || calls() != 3 {
+		t.Fatalf("got %v after %d attempts", result, calls())
+	}
+}
+
+func TestSetRetryBacksOff(t *testing.T) {
+	clt, calls := failingServer(t, 2, http.StatusServiceUnavailable, "")
+	clt.SetRetry(3, 10*time.Millisecond)
+
+	start := time.Now()
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	// Waits 10ms, then 20ms
+	if elapsed := time.Since(start); calls() != 3 || elapsed < 30*time.Millisecond {
+		t.Fatalf("made %d attempts in %v", calls(), elapsed)
+	}
+}
+
+func TestSetRetryGivesUp(t *testing.T) {
+	clt, calls := failingServer(t, 5, http.StatusServiceUnavailable, "0")
+	clt.SetRetry(3, time.Millisecond)
+
+	_, err := clt.Call("GET", "/health", nil, nil)
+	var exception *AppwriteException
+	if !errors.As(err, &exception) || exception.Code != http.StatusServiceUnavailable || calls() != 3 {
+		t.Fatalf("got %v after %d attempts", err, calls())
+	}
+}
+
+func TestSetRetryStopsOnCancel(t *testing.T) {
+	clt, calls := failingServer(t, 5, http.StatusServiceUnavailable, "")
+	clt.SetRetry(5, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := clt.CallWithContext(ctx, "GET", "/health", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v", err)
+	}
+	if calls() != 1 {
+		t.Fatalf("made %d attempts", calls())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		delay time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"2", 2 * time.Second, true},
+		{"0.5", 500 * time.Millisecond, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+	}
+	for _, test := range tests {
+		delay, ok := parseRetryAfter(test.value)
+		if delay != test.delay || ok != test.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v", test.value, delay, ok)
+		}
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if delay, ok := parseRetryAfter(date); !ok || delay <= 0 || delay > time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %v, %v", date, delay, ok)
+	}
+}
+
+func TestSetRetrySkipsPostByDefault(t *testing.T) {
+	clt, calls := failingServer(t, 2, http.StatusServiceUnavailable, "")
+	clt.SetRetry(3, time.Millisecond)
+
+	if _, err := clt.Call("POST", "/teams", nil, map[string]interface{}{"name": "a"}); err == nil {
+		t.Fatal("expected the failure to be returned")
+	}
+	if got := calls(); got != 1 {
+		t.Fatalf("sent %d requests", got)
+	}
+}
+
+func TestSetRetryRetriesIdempotentMethods(t *testing.T) {
+	for _, method := range []string{"GET", "HEAD", "PUT", "DELETE"} {
+		clt, calls := failingServer(t, 2, http.StatusServiceUnavailable, "")
+		clt.SetRetry(3, time.Millisecond)
+
+		if _, err := clt.Call(method, "/teams/t1", nil, nil); err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if got := calls(); got != 3 {
+			t.Errorf("%s: sent %d requests", method, got)
+		}
+	}
+}
+
+func TestSetRetryRetriesPostWithIdempotencyKey(t *testing.T) {
+	clt, calls := failingServer(t, 2, http.StatusServiceUnavailable, "")
+	clt.SetRetry(3, time.Millisecond)
+
+	options := CallOptions{IdempotencyKey: "create-team-a"}
+	if _, err := clt.CallWithOptions("POST", "/teams", map[string]interface{}{"name": "a"}, options); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls(); got != 3 {
+		t.Fatalf("sent %d requests", got)
+	}
+}
@@ -0,0 +1,51 @@
+package appwrite
+
+// Role builds the role strings used by Permission
+type Role struct{}
+
+// Any grants access to anyone, authenticated or not
+func (r Role) Any() string {
+	return "any"
+}
+
+// User grants access to a specific user. An optional status, such as
+// "verified" or "unverified", limits it to users with that status
+func (r Role) User(id string, status ...string) string {
+	if len(status) > 0 && status[0] != "" {
+		return "user:" + id + "/" + status[0]
+	}
+	return "user:" + id
+}
+
+// Users grants access to any authenticated user. An optional status, such as
+// "verified" or "unverified", limits it to users with that status
+func (r Role) Users(status ...string) string {
+	if len(status) > 0 && status[0] != "" {
+		return "users/" + status[0]
+	}
+	return "users"
+}
+
+// Guests grants access to unauthenticated users only
+func (r Role) Guests() string {
+	return "guests"
+}
+
+// Team grants access to the members of a team. An optional role limits it
+// to the members holding that team role
+func (r Role) Team(id string, role ...string) string {
+	if len(role) > 0 && role[0] != "" {
+		return "team:" + id + "/" + role[0]
+	}
+	return "team:" + id
+}
+
+// Member grants access to a specific team membership
+func (r Role) Member(id string) string {
+	return "member:" + id
+}
+
+// Label grants access to users having the given label
+func (r Role) Label(name string) string {
+	return "label:" + name
+}
@@ -0,0 +1,30 @@
+package appwrite
+
+import (
+	"testing"
+)
+
+func TestRole(t *testing.T) {
+	r := Role{}
+	tests := []struct {
+		got  string
+		want string
+	}{
+		{r.Any(), "any"},
+		{r.User("123"), "user:123"},
+		{r.User("123", "verified"), "user:123/verified"},
+		{r.User("123", ""), "user:123"},
+		{r.Users(), "users"},
+		{r.Users("unverified"), "users/unverified"},
+		{r.Guests(), "guests"},
+		{r.Team("t1"), "team:t1"},
+		{r.Team("t1", "owner"), "team:t1/owner"},
+		{r.Member("m1"), "member:m1"},
+		{r.Label("admin"), "label:admin"},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got %s, want %s", test.got, test.want)
+		}
+	}
+}
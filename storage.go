@@ -1,6 +1,12 @@
 package appwrite
 
 import (
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -36,7 +42,7 @@ func (srv *Storage) ListFiles(Search string, Limit int, Offset int, OrderType st
 // CreateFile create a new file. The user who creates the file will
 // automatically be assigned to read and write access unless he has passed
 // custom values for read and write arguments.
-func (srv *Storage) CreateFile(File string, Read []interface{}, Write []interface{}) (map[string]interface{}, error) {
+func (srv *Storage) CreateFile(File InputFile, Read []interface{}, Write []interface{}) (map[string]interface{}, error) {
 	path := "/storage/files"
 
 	params := map[string]interface{}{
@@ -48,6 +54,62 @@ func (srv *Storage) CreateFile(File string, Read []interface{}, Write []interfac
 	return srv.client.Call("POST", path, nil, params)
 }
 
+// CreateFileFromPath upload the file at the local path FilePath to the
+// bucket. The file name is taken from the path and its MIME type guessed from
+// the extension or sniffed from its content. Files larger than 5MB are
+// uploaded in chunks.
+func (srv *Storage) CreateFileFromPath(BucketId string, FileId string, FilePath string, Permissions []string) (map[string]interface{}, error) {
+	file, err := os.Open(FilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType, err := sniffMimeType(file)
+	if err != nil {
+		return nil, err
+	}
+
+	r := strings.NewReplacer("{bucketId}", url.PathEscape(BucketId))
+	path := r.Replace("/storage/buckets/{bucketId}/files")
+
+	params := map[string]interface{}{
+		"fileId":      FileId,
+		"permissions": Permissions,
+	}
+
+	inputFile := InputFile{
+		Name:     filepath.Base(FilePath),
+		Reader:   file,
+		MimeType: mimeType,
+	}
+
+	return srv.client.UploadFile(path, nil, params, "file", inputFile, info.Size(), UploadOptions{})
+}
+
+// sniffMimeType returns the MIME type of file, guessed from its extension or
+// else from its first 512 bytes, leaving file at its start
+func sniffMimeType(file *os.File) (string, error) {
+	if mimeType := mime.TypeByExtension(filepath.Ext(file.Name())); mimeType != "" {
+		return mimeType, nil
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
 // GetFile get file by its unique ID. This endpoint response returns a JSON
 // object with the file metadata.
 func (srv *Storage) GetFile(FileId string) (map[string]interface{}, error) {
@@ -74,26 +136,84 @@ func (srv *Storage) UpdateFile(FileId string, Read []interface{}, Write []interf
 }
 
 // DeleteFile delete a file by its unique ID. Only users with write
-// permissions have access to delete this resource.
-func (srv *Storage) DeleteFile(FileId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
-	path := r.Replace("/storage/files/{fileId}")
+// permissions have access to delete this resource. A nil error means the
+// file was deleted.
+func (srv *Storage) DeleteFile(BucketId string, FileId string) error {
+	r := strings.NewReplacer("{bucketId}", url.PathEscape(BucketId), "{fileId}", url.PathEscape(FileId))
+	path := r.Replace("/storage/buckets/{bucketId}/files/{fileId}")
 
 	params := map[string]interface{}{}
 
-	return srv.client.Call("DELETE", path, nil, params)
+	_, err := srv.client.Call("DELETE", path, nil, params)
+	return err
 }
 
 // GetFileDownload get file content by its unique ID. The endpoint response
 // return with a 'Content-Disposition: attachment' header that tells the
-// browser to start downloading the file to user downloads directory.
-func (srv *Storage) GetFileDownload(FileId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
-	path := r.Replace("/storage/files/{fileId}/download")
+// browser to start downloading the file to user downloads directory. The
+// content is streamed, and the returned body must be closed by the caller.
+func (srv *Storage) GetFileDownload(BucketId string, FileId string) (io.ReadCloser, error) {
+	r := strings.NewReplacer("{bucketId}", url.PathEscape(BucketId), "{fileId}", url.PathEscape(FileId))
+	path := r.Replace("/storage/buckets/{bucketId}/files/{fileId}/download")
 
 	params := map[string]interface{}{}
 
-	return srv.client.Call("GET", path, nil, params)
+	body, _, err := srv.client.CallStream("GET", path, nil, params)
+	return body, err
+}
+
+// FilePreviewOptions holds the transformations applied by GetFilePreview.
+// Options left to their zero value are not sent, keeping the server
+// defaults
+type FilePreviewOptions struct {
+	Width        int
+	Height       int
+	Gravity      string
+	Quality      int
+	BorderWidth  int
+	BorderColor  string
+	BorderRadius int
+	Opacity      float64
+	Rotation     int
+	Background   string
+	Output       string
+}
+
+// params returns the non-zero options as query params
+func (options FilePreviewOptions) params() map[string]interface{} {
+	params := map[string]interface{}{}
+
+	ints := map[string]int{
+		"width":        options.Width,
+		"height":       options.Height,
+		"quality":      options.Quality,
+		"borderWidth":  options.BorderWidth,
+		"borderRadius": options.BorderRadius,
+		"rotation":     options.Rotation,
+	}
+	for key, val := range ints {
+		if val != 0 {
+			params[key] = val
+		}
+	}
+
+	strs := map[string]string{
+		"gravity":     options.Gravity,
+		"borderColor": options.BorderColor,
+		"background":  options.Background,
+		"output":      options.Output,
+	}
+	for key, val := range strs {
+		if val != "" {
+			params[key] = val
+		}
+	}
+
+	if options.Opacity != 0 {
+		params["opacity"] = options.Opacity
+	}
+
+	return params
 }
 
 // GetFilePreview get a file preview image. Currently, this method supports
@@ -101,31 +221,54 @@ func (srv *Storage) GetFileDownload(FileId string) (map[string]interface{}, erro
 // pdf, docs, slides, and spreadsheets, will return the file icon image. You
 // can also pass query string arguments for cutting and resizing your preview
 // image.
-func (srv *Storage) GetFilePreview(FileId string, Width int, Height int, Quality int, Background string, Output string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
-	path := r.Replace("/storage/files/{fileId}/preview")
+func (srv *Storage) GetFilePreview(BucketId string, FileId string, Options FilePreviewOptions) ([]byte, error) {
+	r := strings.NewReplacer("{bucketId}", url.PathEscape(BucketId), "{fileId}", url.PathEscape(FileId))
+	path := r.Replace("/storage/buckets/{bucketId}/files/{fileId}/preview")
 
-	params := map[string]interface{}{
-		"width":      Width,
-		"height":     Height,
-		"quality":    Quality,
-		"background": Background,
-		"output":     Output,
+	params := Options.params()
+
+	return srv.client.callBytes("GET", path, nil, params)
+}
+
+// GetFilePreviewIfNoneMatch is GetFilePreview revalidating a cached preview
+// against its ETag. The preview is returned along with its ETag, unless the
+// cached copy is still current, in which case ErrNotModified is returned
+// with the ETag instead. An empty ETag always fetches the preview.
+func (srv *Storage) GetFilePreviewIfNoneMatch(BucketId string, FileId string, Options FilePreviewOptions, ETag string) ([]byte, string, error) {
+	r := strings.NewReplacer("{bucketId}", url.PathEscape(BucketId), "{fileId}", url.PathEscape(FileId))
+	path := r.Replace("/storage/buckets/{bucketId}/files/{fileId}/preview")
+
+	headers := map[string]interface{}{}
+	if ETag != "" {
+		headers["If-None-Match"] = ETag
 	}
+	params := Options.params()
 
-	return srv.client.Call("GET", path, nil, params)
+	body, responseHeaders, err := srv.client.CallStream("GET", path, headers, params)
+	if err != nil {
+		return nil, responseHeaders.Get("ETag"), err
+	}
+	defer body.Close()
+
+	image, err := readLimited(body, srv.client.responseLimit())
+	if err != nil {
+		return nil, "", err
+	}
+	return image, responseHeaders.Get("ETag"), nil
 }
 
 // GetFileView get file content by its unique ID. This endpoint is similar to
 // the download method but returns with no  'Content-Disposition: attachment'
-// header.
-func (srv *Storage) GetFileView(FileId string, As string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{fileId}", FileId)
-	path := r.Replace("/storage/files/{fileId}/view")
+// header. The content is streamed, and the returned body must be closed by
+// the caller.
+func (srv *Storage) GetFileView(BucketId string, FileId string, As string) (io.ReadCloser, error) {
+	r := strings.NewReplacer("{bucketId}", url.PathEscape(BucketId), "{fileId}", url.PathEscape(FileId))
+	path := r.Replace("/storage/buckets/{bucketId}/files/{fileId}/view")
 
 	params := map[string]interface{}{
 		"as": As,
 	}
 
-	return srv.client.Call("GET", path, nil, params)
+	body, _, err := srv.client.CallStream("GET", path, nil, params)
+	return body, err
 }
@@ -0,0 +1,181 @@
+package appwrite
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreateFileFromPath(t *testing.T) {
+	var filename, contentType, fileId string
+	var permissions []string
+	var size int
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		filename, contentType, size = header.Filename, header.Header.Get("Content-Type"), len(data)
+		fileId, permissions = r.FormValue("fileId"), r.MultipartForm.Value["permissions[]"]
+		writeJSON(w, http.StatusCreated, `{"$id":"f"}`)
+	})
+
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("hello world"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := NewStorage(clt)
+	perms := []string{`read("any")`, `update("users")`}
+	if _, err := srv.CreateFileFromPath("b", "f", path, perms); err != nil {
+		t.Fatal(err)
+	}
+
+	if filename != "notes.txt" || size != 11 || contentType != "text/plain; charset=utf-8" {
+		t.Fatalf("received %q of %d bytes as %q", filename, size, contentType)
+	}
+	if fileId != "f" || !reflect.DeepEqual(permissions, perms) {
+		t.Fatalf("received file id %q and permissions %q", fileId, permissions)
+	}
+}
+
+func TestCreateFileFromPathMissingFile(t *testing.T) {
+	srv := NewStorage(NewClient(WithProject("test")))
+	if _, err := srv.CreateFileFromPath("b", "f", filepath.Join(t.TempDir(), "missing"), nil); !os.IsNotExist(err) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestGetFilePreview(t *testing.T) {
+	var query url.Values
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/storage/buckets/b/files/f1/preview" {
+			t.Errorf("got path %q", r.URL.Path)
+		}
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+	})
+	srv := NewStorage(clt)
+
+	image, err := srv.GetFilePreview("b", "f1", FilePreviewOptions{Width: 200, Gravity: "center", Opacity: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(image) != "png" {
+		t.Fatalf("got image %q", image)
+	}
+	want := url.Values{"width": {"200"}, "gravity": {"center"}, "opacity": {"0.5"}}
+	if !reflect.DeepEqual(query, want) {
+		t.Fatalf("sent query %v, want %v", query, want)
+	}
+
+	if _, err := srv.GetFilePreview("b", "f1", FilePreviewOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(query) != 0 {
+		t.Fatalf("sent omitted options %v", query)
+	}
+}
+
+func TestGetFilePreviewIfNoneMatch(t *testing.T) {
+	var ifNoneMatch string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = r.Header.Get("If-None-Match")
+		w.Header().Set("ETag", `"v1"`)
+		if ifNoneMatch == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+	})
+	srv := NewStorage(clt)
+
+	image, etag, err := srv.GetFilePreviewIfNoneMatch("b", "f1", FilePreviewOptions{Width: 200}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(image) != "png" || etag != `"v1"` || ifNoneMatch != "" {
+		t.Fatalf("got image %q with ETag %q, sent If-None-Match %q", image, etag, ifNoneMatch)
+	}
+
+	image, etag, err = srv.GetFilePreviewIfNoneMatch("b", "f1", FilePreviewOptions{Width: 200}, etag)
+	if !errors.Is(err, ErrNotModified) {
+		t.Fatalf("got %v, want ErrNotModified", err)
+	}
+	if image != nil || etag != `"v1"` || ifNoneMatch != `"v1"` {
+		t.Fatalf("got image %q with ETag %q, sent If-None-Match %q", image, etag, ifNoneMatch)
+	}
+}
+
+func TestDeleteFile(t *testing.T) {
+	var method, path string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		if r.URL.Path == "/v1/storage/buckets/b/files/missing" {
+			writeJSON(w, http.StatusNotFound, `{"message":"The requested file could not be found.","code":404,"type":"storage_file_not_found"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := NewStorage(clt)
+
+	if err := srv.DeleteFile("b", "f1"); err != nil {
+		t.Fatal(err)
+	}
+	if method != "DELETE" || path != "/v1/storage/buckets/b/files/f1" {
+		t.Fatalf("sent %s %s", method, path)
+	}
+
+	err := srv.DeleteFile("b", "missing")
+	var exception *AppwriteException
+	if !errors.As(err, &exception) || exception.Code != http.StatusNotFound {
+		t.Fatalf("got %v", err)
+	}
+	if !IsType(err, ErrorTypeStorageFileNotFound) {
+		t.Fatalf("got type %q", exception.Type)
+	}
+}
+
+func TestGetFileDownloadAndView(t *testing.T) {
+	pdf := "%PDF-1.4\n" + strings.Repeat("\x00\xff", 512)
+	var paths []string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/pdf")
+		io.WriteString(w, pdf)
+	})
+	srv := NewStorage(clt)
+
+	download, err := srv.GetFileDownload("b", "f/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer download.Close()
+	if data, _ := io.ReadAll(download); string(data) != pdf {
+		t.Fatalf("downloaded %d bytes", len(data))
+	}
+
+	view, err := srv.GetFileView("b", "f/1", "pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer view.Close()
+	if data, _ := io.ReadAll(view); string(data) != pdf {
+		t.Fatalf("viewed %d bytes", len(data))
+	}
+
+	want := []string{"/v1/storage/buckets/b/files/f%2F1/download", "/v1/storage/buckets/b/files/f%2F1/view"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("sent paths %q", paths)
+	}
+}
@@ -1,6 +1,7 @@
 package appwrite
 
 import (
+	"net/url"
 	"strings"
 )
 
@@ -52,7 +53,7 @@ func (srv *Teams) Create(Name string, Roles []interface{}) (map[string]interface
 // Get get team by its unique ID. All team members have read access for this
 // resource.
 func (srv *Teams) Get(TeamId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	r := strings.NewReplacer("{teamId}", url.PathEscape(TeamId))
 	path := r.Replace("/teams/{teamId}")
 
 	params := map[string]interface{}{}
@@ -63,7 +64,7 @@ func (srv *Teams) Get(TeamId string) (map[string]interface{}, error) {
 // Update update team by its unique ID. Only team owners have write access for
 // this resource.
 func (srv *Teams) Update(TeamId string, Name string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	r := strings.NewReplacer("{teamId}", url.PathEscape(TeamId))
 	path := r.Replace("/teams/{teamId}")
 
 	params := map[string]interface{}{
@@ -76,7 +77,7 @@ func (srv *Teams) Update(TeamId string, Name string) (map[string]interface{}, er
 // Delete delete team by its unique ID. Only team owners have write access for
 // this resource.
 func (srv *Teams) Delete(TeamId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	r := strings.NewReplacer("{teamId}", url.PathEscape(TeamId))
 	path := r.Replace("/teams/{teamId}")
 
 	params := map[string]interface{}{}
@@ -87,7 +88,7 @@ func (srv *Teams) Delete(TeamId string) (map[string]interface{}, error) {
 // GetMemberships get team members by the team unique ID. All team members
 // have read access for this list of resources.
 func (srv *Teams) GetMemberships(TeamId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	r := strings.NewReplacer("{teamId}", url.PathEscape(TeamId))
 	path := r.Replace("/teams/{teamId}/memberships")
 
 	params := map[string]interface{}{}
@@ -110,7 +111,7 @@ func (srv *Teams) GetMemberships(TeamId string) (map[string]interface{}, error)
 // the only valid redirect URL's are the once from domains you have set when
 // added your platforms in the console interface.
 func (srv *Teams) CreateMembership(TeamId string, Email string, Roles []interface{}, Url string, Name string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId)
+	r := strings.NewReplacer("{teamId}", url.PathEscape(TeamId))
 	path := r.Replace("/teams/{teamId}/memberships")
 
 	params := map[string]interface{}{
@@ -127,10 +128,25 @@ func (srv *Teams) CreateMembership(TeamId string, Email string, Roles []interfac
 // owner to delete the membership of any other team member. You can also use
 // this endpoint to delete a user membership even if he didn't accept it.
 func (srv *Teams) DeleteMembership(TeamId string, InviteId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{teamId}", TeamId, "{inviteId}", InviteId)
+	r := strings.NewReplacer("{teamId}", url.PathEscape(TeamId), "{inviteId}", url.PathEscape(InviteId))
 	path := r.Replace("/teams/{teamId}/memberships/{inviteId}")
 
 	params := map[string]interface{}{}
 
 	return srv.client.Call("DELETE", path, nil, params)
 }
+
+// UpdateMembershipStatus use this endpoint to allow a user to accept an
+// invitation to join a team after being redirected back to your app from the
+// invitation email received by the user.
+func (srv *Teams) UpdateMembershipStatus(TeamId string, MembershipId string, UserId string, Secret string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{teamId}", url.PathEscape(TeamId), "{membershipId}", url.PathEscape(MembershipId))
+	path := r.Replace("/teams/{teamId}/memberships/{membershipId}/status")
+
+	params := map[string]interface{}{
+		"userId": UserId,
+		"secret": Secret,
+	}
+
+	return srv.client.Call("PATCH", path, nil, params)
+}
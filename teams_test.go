@@ -0,0 +1,54 @@
+package appwrite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTeamsCreateMembership(t *testing.T) {
+	clt, last := recordRequests(t, `{"$id":"m1"}`)
+	srv := NewTeams(clt)
+
+	if _, err := srv.CreateMembership("team 1", "a@b.c", []interface{}{"owner", "developer"}, "https://example.com/join", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+
+	req := last()
+	if req.Method != "POST" || req.Path != "/v1/teams/team%201/memberships" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if !reflect.DeepEqual(req.Body["roles"], []interface{}{"owner", "developer"}) {
+		t.Fatalf("sent roles %v", req.Body["roles"])
+	}
+	if req.Body["email"] != "a@b.c" || req.Body["url"] != "https://example.com/join" {
+		t.Fatalf("sent %v", req.Body)
+	}
+}
+
+func TestTeamsPaths(t *testing.T) {
+	clt, last := recordRequests(t, `{}`)
+	srv := NewTeams(clt)
+
+	tests := []struct {
+		name   string
+		call   func() (map[string]interface{}, error)
+		method string
+		path   string
+	}{
+		{"List", func() (map[string]interface{}, error) { return srv.List("", 0, 0, "") }, "GET", "/v1/teams"},
+		{"Create", func() (map[string]interface{}, error) { return srv.Create("Team", nil) }, "POST", "/v1/teams"},
+		{"Get", func() (map[string]interface{}, error) { return srv.Get("t/1") }, "GET", "/v1/teams/t%2F1"},
+		{"Delete", func() (map[string]interface{}, error) { return srv.Delete("t/1") }, "DELETE", "/v1/teams/t%2F1"},
+		{"UpdateMembershipStatus", func() (map[string]interface{}, error) {
+			return srv.UpdateMembershipStatus("t/1", "m?1", "u1", "secret")
+		}, "PATCH", "/v1/teams/t%2F1/memberships/m%3F1/status"},
+	}
+	for _, test := range tests {
+		if _, err := test.call(); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if req := last(); req.Method != test.method || req.Path != test.path {
+			t.Errorf("%s: sent %s %s, want %s %s", test.name, req.Method, req.Path, test.method, test.path)
+		}
+	}
+}
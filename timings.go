@@ -0,0 +1,95 @@
+package appwrite
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timings break down how long a call took. Phases skipped by a reused
+// connection, such as DNS and Connect, are zero
+type Timings struct {
+	// DNS is the time spent resolving the host name
+	DNS time.Duration
+	// Connect is the time spent opening the TCP connection
+	Connect time.Duration
+	// TLSHandshake is the time spent on the TLS handshake
+	TLSHandshake time.Duration
+	// FirstByte is the time from sending the request until the first byte
+	// of the response arrived
+	FirstByte time.Duration
+	// Total is the time from the start of the call until the response body
+	// was read, including retries
+	Total time.Duration
+}
+
+// SetTimings enables Timings on the responses returned by CallWithResponse.
+// Timings are off by default, sparing the tracing overhead
+func (clt *Client) SetTimings(enabled bool) {
+	clt.configure(func(cfg *clientConfig) {
+		cfg.timings = enabled
+	})
+}
+
+// timingTrace collects Timings from the httptrace hooks of a call
+type timingTrace struct {
+	mu      sync.Mutex
+	timings Timings
+
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+}
+
+// newTimingTrace starts timing a call, returning ctx with the trace hooks
+func newTimingTrace(ctx context.Context) (context.Context, *timingTrace) {
+	trace := &timingTrace{start: time.Now()}
+
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			trace.record(func() { trace.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			trace.record(func() { trace.timings.DNS = time.Since(trace.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			trace.record(func() { trace.connectStart = time.Now() })
+		},
+		ConnectDone: func(string, string, error) {
+			trace.record(func() { trace.timings.Connect = time.Since(trace.connectStart) })
+		},
+		TLSHandshakeStart: func() {
+			trace.record(func() { trace.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			trace.record(func() { trace.timings.TLSHandshake = time.Since(trace.tlsStart) })
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			trace.record(func() { trace.wroteRequest = time.Now() })
+		},
+		GotFirstResponseByte: func() {
+			trace.record(func() { trace.timings.FirstByte = time.Since(trace.wroteRequest) })
+		},
+	}), trace
+}
+
+func (trace *timingTrace) record(update func()) {
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+
+	update()
+}
+
+// finish returns the Timings of the call, ending it now
+func (trace *timingTrace) finish() *Timings {
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+
+	timings := trace.timings
+	timings.Total = time.Since(trace.start)
+	return &timings
+}
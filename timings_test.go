@@ -0,0 +1,39 @@
+package appwrite
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSetTimings(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	response, err := clt.CallWithResponse(context.Background(), "GET", "/health", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Timings != nil {
+		t.Fatalf("got timings %+v while disabled", response.Timings)
+	}
+
+	clt.SetTimings(true)
+	response, err = clt.CallWithResponse(context.Background(), "GET", "/health", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timings := response.Timings
+	if timings == nil {
+		t.Fatal("no timings were returned")
+	}
+	if timings.Total < 50*time.Millisecond || timings.Total > time.Second {
+		t.Fatalf("got total %v for a 50ms handler", timings.Total)
+	}
+	if timings.FirstByte < 50*time.Millisecond || timings.FirstByte > timings.Total {
+		t.Fatalf("got first byte after %v of %v", timings.FirstByte, timings.Total)
+	}
+}
@@ -0,0 +1,76 @@
+package appwrite
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// SetTransportOptions tunes the connection pool of the Client, keeping up to
+// maxIdleConnsPerHost idle connections per host for at most idleConnTimeout.
+// Zero values keep the defaults of http.DefaultTransport. The options combine
+// with SetSelfSigned and have no effect on a client injected with
+// SetHTTPClient
+func (clt *Client) SetTransportOptions(maxIdleConnsPerHost int, idleConnTimeout time.Duration) {
+	mu := clt.lock()
+	mu.Lock()
+	defer mu.Unlock()
+
+	clt.http.maxIdleConnsPerHost = maxIdleConnsPerHost
+	clt.http.idleConnTimeout = idleConnTimeout
+	clt.http.resetOwnClient()
+}
+
+// httpState holds the *http.Client of a Client along with the settings its
+// own client is built from. It is guarded by the Client lock
+type httpState struct {
+	client     *http.Client
+	selfSigned bool
+	timeout    time.Duration
+
+	// custom is set when client was injected with SetHTTPClient
+	custom              bool
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+}
+
+// newTransport builds the transport of the Client's own *http.Client, or
+// returns nil to use http.DefaultTransport when nothing needs tuning
+func (state *httpState) newTransport() http.RoundTripper {
+	if !state.selfSigned && state.maxIdleConnsPerHost == 0 && state.idleConnTimeout == 0 {
+		return nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if state.selfSigned {
+		// Accept self-signed certificates from self-hosted servers
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	if state.maxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = state.maxIdleConnsPerHost
+	}
+	if state.idleConnTimeout > 0 {
+		transport.IdleConnTimeout = state.idleConnTimeout
+	}
+	return transport
+}
+
+// resetOwnClient drops the Client's own *http.Client so it is rebuilt with
+// the current transport settings on the next call. The caller must hold the
+// Client lock
+func (state *httpState) resetOwnClient() {
+	if !state.custom {
+		state.client = nil
+	}
+}
+
+// Close closes the idle connections kept by the Client's *http.Client, for
+// instance on server shutdown. Connections in use are left open, and the
+// Client can still be used afterwards, opening new connections as needed.
+// Close never fails and is a no-op for a Client that made no call yet
+func (clt *Client) Close() error {
+	if client := clt.httpClient(); client != nil {
+		client.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package appwrite
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetSelfSigned(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"status":"pass"}`)
+	}))
+	defer server.Close()
+
+	clt := NewClient(WithProject("test"), WithEndpoint(server.URL))
+	if _, err := clt.Call("GET", "/health", nil, nil); err == nil {
+		t.Fatal("a self-signed certificate was accepted by default")
+	}
+
+	clt.SetSelfSigned(true)
+	result, err := clt.Call("GET", "/health", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["status"] != "pass" {
+		t.Fatalf("got %v", result)
+	}
+
+	clt.SetSelfSigned(false)
+	if _, err := clt.Call("GET", "/health", nil, nil); err == nil {
+		t.Fatal("a self-signed certificate was accepted once verification was restored")
+	}
+}
+
+func TestSetTransportOptions(t *testing.T) {
+	clt := NewClient()
+	if transport := clt.http.newTransport(); transport != nil {
+		t.Fatalf("got transport %v without options", transport)
+	}
+
+	clt.SetTransportOptions(64, 30*time.Second)
+	clt.SetSelfSigned(true)
+	transport, ok := clt.http.newTransport().(*http.Transport)
+	if !ok {
+		t.Fatal("no *http.Transport was built")
+	}
+	if transport.MaxIdleConnsPerHost != 64 || transport.IdleConnTimeout != 30*time.Second {
+		t.Fatalf("got %d idle connections for %v", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("the self-signed TLS config was lost")
+	}
+}
+
+func TestClose(t *testing.T) {
+	var zero Client
+	if err := zero.Close(); err != nil {
+		t.Fatal(err)
+	}
+	fresh := NewClient()
+	if err := fresh.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := clt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clt.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatalf("the client can't be reused after Close: %v", err)
+	}
+}
+
+func TestServicesShareHTTPClient(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+	clt.SetTransportOptions(8, time.Minute)
+	health := NewHealth(clt)
+	if _, err := health.client.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	client := clt.httpClient()
+	if client == nil || client != health.client.httpClient() {
+		t.Fatal("the service opened its own *http.Client")
+	}
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok || transport.MaxIdleConnsPerHost != 8 {
+		t.Fatalf("got transport %v", client.Transport)
+	}
+
+	clt.SetTransportOptions(16, time.Minute)
+	if _, err := health.client.Call("GET", "/health", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if transport := health.client.httpClient().Transport.(*http.Transport); transport.MaxIdleConnsPerHost != 16 {
+		t.Fatalf("the service kept %d idle connections per host", transport.MaxIdleConnsPerHost)
+	}
+}
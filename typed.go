@@ -0,0 +1,96 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Unmarshal decodes a response returned by Call into out, letting callers
+// work with their own structs instead of maps
+func Unmarshal[T any](resp map[string]interface{}, out *T) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// CallTyped calls an API using clt and decodes the response body directly
+// into a value of type T
+func CallTyped[T any](ctx context.Context, clt *Client, method string, path string, headers map[string]interface{}, params map[string]interface{}) (T, error) {
+	var out T
+
+	response, err := clt.send(ctx, method, path, headers, params)
+	if err != nil {
+		return out, err
+	}
+	defer response.Body.Close()
+
+	body, err := readJSONBody(response, clt.responseLimit())
+	if err != nil || body == nil {
+		return out, err
+	}
+
+	if err := json.Unmarshal(body, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// ParseList decodes a list response, returning its total and the items
+// stored under key, such as "documents", "files" or "users"
+func ParseList[T any](resp map[string]interface{}, key string) (int64, []T, error) {
+	var list struct {
+		Total int64 `json:"total"`
+	}
+	if err := Unmarshal(resp, &list); err != nil {
+		return 0, nil, err
+	}
+
+	raw, ok := resp[key]
+	if !ok {
+		return list.Total, nil, fmt.Errorf("list response has no %q key", key)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return 0, nil, err
+	}
+	return list.Total, items, nil
+}
+
+// Page is a single page of documents decoded into T
+type Page[T any] struct {
+	Total     int64
+	Documents []T
+
+	lastID string
+}
+
+// LastID returns the $id of the last document of the page, to pass to
+// CursorAfter when requesting the next page. It is empty for an empty page
+func (page Page[T]) LastID() string {
+	return page.lastID
+}
+
+// parsePage decodes the list stored under key in resp into a Page
+func parsePage[T any](resp map[string]interface{}, key string) (Page[T], error) {
+	total, items, err := ParseList[T](resp, key)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	page := Page[T]{Total: total, Documents: items}
+	if raw, _ := resp[key].([]interface{}); len(raw) > 0 {
+		if last, ok := raw[len(raw)-1].(map[string]interface{}); ok {
+			page.lastID, _ = DocumentID(last)
+		}
+	}
+	return page, nil
+}
@@ -0,0 +1,111 @@
+package appwrite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+type testDocument struct {
+	ID    string `json:"$id"`
+	Title string `json:"title"`
+	Views int    `json:"views"`
+}
+
+type testDocumentList struct {
+	Total     int            `json:"total"`
+	Documents []testDocument `json:"documents"`
+}
+
+const testDocumentsBody = `{"total":2,"documents":[{"$id":"a","title":"First","views":1},{"$id":"b","title":"Second","views":2}]}`
+
+func TestCallTyped(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, testDocumentsBody)
+	})
+
+	list, err := CallTyped[testDocumentList](context.Background(), &clt, "GET", "/databases/db/collections/c/documents", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 2 || len(list.Documents) != 2 {
+		t.Fatalf("got %+v", list)
+	}
+	if list.Documents[1] != (testDocument{ID: "b", Title: "Second", Views: 2}) {
+		t.Fatalf("got %+v", list.Documents[1])
+	}
+}
+
+func TestCallTypedTypeMismatch(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"total":"two"}`)
+	})
+
+	if _, err := CallTyped[testDocumentList](context.Background(), &clt, "GET", "/databases/db/collections/c/documents", nil, nil); err == nil {
+		t.Fatal("decoded a string into an int")
+	}
+}
+
+func TestCallTypedUnexpectedContentType(t *testing.T) {
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html>maintenance</html>"))
+	})
+
+	_, err := CallTyped[testDocumentList](context.Background(), &clt, "GET", "/databases/db/collections/c/documents", nil, nil)
+	if err == nil {
+		t.Fatal("decoded an HTML page")
+	}
+	if got, want := err.Error(), "unexpected content-type text/html: <html>maintenance</html>"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	var document testDocument
+	if err := Unmarshal(map[string]interface{}{"$id": "a", "views": 3}, &document); err != nil {
+		t.Fatal(err)
+	}
+	if document.ID != "a" || document.Views != 3 {
+		t.Fatalf("got %+v", document)
+	}
+
+	if err := Unmarshal(map[string]interface{}{"views": "many"}, &document); err == nil {
+		t.Fatal("decoded a string into an int")
+	}
+}
+
+func TestParseList(t *testing.T) {
+	var documents map[string]interface{}
+	if err := json.Unmarshal([]byte(testDocumentsBody), &documents); err != nil {
+		t.Fatal(err)
+	}
+	total, items, err := ParseList[testDocument](documents, "documents")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(items) != 2 || items[0] != (testDocument{ID: "a", Title: "First", Views: 1}) {
+		t.Fatalf("got %d %+v", total, items)
+	}
+
+	type user struct {
+		ID    string `json:"$id"`
+		Email string `json:"email"`
+	}
+	users := map[string]interface{}{
+		"total": 1,
+		"users": []interface{}{map[string]interface{}{"$id": "u1", "email": "a@b.c"}},
+	}
+	total, found, err := ParseList[user](users, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(found) != 1 || found[0] != (user{ID: "u1", Email: "a@b.c"}) {
+		t.Fatalf("got %d %+v", total, found)
+	}
+
+	if _, _, err := ParseList[user](users, "documents"); err == nil {
+		t.Fatal("expected an error for a missing key")
+	}
+}
@@ -0,0 +1,230 @@
+package appwrite
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// chunkSize is the largest piece of a file sent in a single upload request
+const chunkSize = 5 * 1024 * 1024
+
+// UploadProgress is the state of a chunked upload. Persisting the last
+// progress received lets an interrupted upload be resumed later
+type UploadProgress struct {
+	// UploadId is the file id returned by the server for the first chunk
+	UploadId string
+	// Uploaded is the number of bytes sent so far
+	Uploaded int64
+	// Total is the size of the file
+	Total int64
+}
+
+// UploadOptions tunes how UploadFile sends a file
+type UploadOptions struct {
+	// Progress, when set, is called after every uploaded chunk
+	Progress func(progress UploadProgress)
+
+	// Resume, when set, continues the chunked upload it describes instead
+	// of starting over. The first Resume.Uploaded bytes of the file are
+	// skipped, seeking when the reader supports it
+	Resume *UploadProgress
+
+	// AutoCleanupOnCancel deletes the partially uploaded file, assumed to
+	// live at path followed by its upload id, when the context of a chunked
+	// upload is cancelled
+	AutoCleanupOnCancel bool
+
+	// VerifyIntegrity computes the MD5 checksum of the file while it is
+	// uploaded and compares it with the signature of the stored file,
+	// returning an error wrapping ErrIntegrityMismatch when they differ
+	VerifyIntegrity bool
+}
+
+// ErrIntegrityMismatch is returned when the signature of an uploaded file
+// does not match the content that was sent
+var ErrIntegrityMismatch = errors.New("uploaded file signature mismatch")
+
+// UploadError is returned when a chunked upload stops after the server
+// created the file, giving callers the upload id needed to resume it or
+// delete the partial file
+type UploadError struct {
+	UploadId string
+	Uploaded int64
+	Err      error
+}
+
+func (e *UploadError) Error() string {
+	return fmt.Sprintf("upload %s stopped after %d bytes: %v", e.UploadId, e.Uploaded, e.Err)
+}
+
+func (e *UploadError) Unwrap() error {
+	return e.Err
+}
+
+// UploadFile uploads size bytes of file to path as the paramName param.
+// Files larger than 5MB are sent in 5MB chunks, each carrying its
+// Content-Range, with the file id returned by the server for the first chunk
+// passed along in the x-appwrite-id header of the following ones. It returns
+// the file metadata from the last response.
+//
+// A size of zero or less is found from the reader: an io.ReadSeeker, such as
+// an *os.File or a *bytes.Reader, is measured without reading it, while any
+// other reader is buffered in memory, which is logged as a warning
+func (clt *Client) UploadFile(path string, headers map[string]interface{}, params map[string]interface{}, paramName string, file InputFile, size int64, options UploadOptions) (map[string]interface{}, error) {
+	return clt.UploadFileWithContext(context.Background(), path, headers, params, paramName, file, size, options)
+}
+
+// UploadFileWithContext is UploadFile stopping between chunks once ctx is
+// cancelled. A chunked upload interrupted after its first chunk returns an
+// *UploadError holding the upload id
+func (clt *Client) UploadFileWithContext(ctx context.Context, path string, headers map[string]interface{}, params map[string]interface{}, paramName string, file InputFile, size int64, options UploadOptions) (map[string]interface{}, error) {
+	if size <= 0 {
+		sized, n, buffered, err := file.withSize()
+		if err != nil {
+			return nil, err
+		}
+		if logger := clt.config().logger; buffered && logger != nil {
+			logger(fmt.Sprintf("warning: buffered %d bytes of %s in memory to find its size, pass an io.ReadSeeker or the size to stream it", n, file.Name))
+		}
+		file, size = sized, n
+	}
+
+	if !options.VerifyIntegrity {
+		return clt.sendFile(ctx, path, headers, params, paramName, file, size, options)
+	}
+
+	// Hash the file as it is sent. Resumed bytes are read rather than
+	// seeked past so they are hashed too
+	hash := md5.New()
+	file.Reader = io.TeeReader(file.Reader, hash)
+
+	result, err := clt.sendFile(ctx, path, headers, params, paramName, file, size, options)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if signature, _ := result["signature"].(string); signature != checksum {
+		return result, fmt.Errorf("%w: file %s has md5 %s but the server stored %q", ErrIntegrityMismatch, file.Name, checksum, signature)
+	}
+	return result, nil
+}
+
+// sendFile uploads file in a single request or in chunks, depending on size
+func (clt *Client) sendFile(ctx context.Context, path string, headers map[string]interface{}, params map[string]interface{}, paramName string, file InputFile, size int64, options UploadOptions) (map[string]interface{}, error) {
+	if size <= chunkSize {
+		uploadParams := copyParams(params)
+		uploadParams[paramName] = file
+
+		result, err := clt.CallWithContext(ctx, "POST", path, headers, uploadParams)
+		if err != nil {
+			return nil, err
+		}
+		if options.Progress != nil {
+			id, _ := result["$id"].(string)
+			options.Progress(UploadProgress{UploadId: id, Uploaded: size, Total: size})
+		}
+		return result, nil
+	}
+
+	var result map[string]interface{}
+	uploadId := ""
+	offset := int64(0)
+	buffer := make([]byte, chunkSize)
+
+	if options.Resume != nil {
+		uploadId = options.Resume.UploadId
+		offset = options.Resume.Uploaded
+		if err := skipReader(file.Reader, offset); err != nil {
+			return nil, err
+		}
+	}
+
+	for offset < size {
+		if err := ctx.Err(); err != nil {
+			return nil, clt.stopUpload(path, headers, uploadId, offset, err, options)
+		}
+
+		n, err := io.ReadFull(file.Reader, buffer[:minInt64(chunkSize, size-offset)])
+		if err != nil {
+			return nil, clt.stopUpload(path, headers, uploadId, offset, err, options)
+		}
+		end := offset + int64(n) - 1
+
+		chunkHeaders := copyParams(headers)
+		chunkHeaders["Content-Range"] = fmt.Sprintf("bytes %d-%d/%d", offset, end, size)
+		if uploadId != "" {
+			chunkHeaders["x-appwrite-id"] = uploadId
+		}
+
+		chunkParams := copyParams(params)
+		chunkParams[paramName] = InputFile{
+			Name:     file.Name,
+			Reader:   bytes.NewReader(buffer[:n]),
+			MimeType: file.MimeType,
+		}
+
+		result, err = clt.CallWithContext(ctx, "POST", path, chunkHeaders, chunkParams)
+		if err != nil {
+			return nil, clt.stopUpload(path, headers, uploadId, offset, err, options)
+		}
+		if id, ok := result["$id"].(string); ok && uploadId == "" {
+			uploadId = id
+		}
+
+		offset = end + 1
+		if options.Progress != nil {
+			options.Progress(UploadProgress{UploadId: uploadId, Uploaded: offset, Total: size})
+		}
+	}
+
+	return result, nil
+}
+
+// stopUpload returns the error ending a chunked upload, wrapped in an
+// UploadError once the server created the file. A partial file left by a
+// cancelled upload is deleted when AutoCleanupOnCancel is set
+func (clt *Client) stopUpload(path string, headers map[string]interface{}, uploadId string, uploaded int64, err error, options UploadOptions) error {
+	if uploadId == "" {
+		return err
+	}
+
+	cancelled := errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+	if cancelled && options.AutoCleanupOnCancel {
+		clt.Call("DELETE", joinURL(path, url.PathEscape(uploadId)), headers, nil)
+	}
+
+	return &UploadError{UploadId: uploadId, Uploaded: uploaded, Err: err}
+}
+
+// skipReader moves reader n bytes past its current offset
+func skipReader(reader io.Reader, n int64) error {
+	if seeker, ok := reader.(io.Seeker); ok {
+		_, err := seeker.Seek(n, io.SeekCurrent)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, reader, n)
+	return err
+}
+
+// copyParams returns a shallow copy of params that can be modified freely
+func copyParams(params map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(params))
+	for key, val := range params {
+		copied[key] = val
+	}
+	return copied
+}
+
+func minInt64(a int64, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,333 @@
+package appwrite
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// uploadedChunk is a chunk received by the server of newUploadServer
+type uploadedChunk struct {
+	contentRange string
+	uploadId     string
+	data         []byte
+}
+
+// newUploadServer starts a server recording the file chunks it receives,
+// answering each with the upload id "upload"
+func newUploadServer(t *testing.T) (Client, func() []uploadedChunk) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var chunks []uploadedChunk
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		data, _ := io.ReadAll(file)
+
+		mu.Lock()
+		chunks = append(chunks, uploadedChunk{
+			contentRange: r.Header.Get("Content-Range"),
+			uploadId:     r.Header.Get("X-Appwrite-Id"),
+			data:         data,
+		})
+		mu.Unlock()
+		writeJSON(w, http.StatusCreated, `{"$id":"upload"}`)
+	})
+
+	return clt, func() []uploadedChunk {
+		mu.Lock()
+		defer mu.Unlock()
+		return chunks
+	}
+}
+
+// testFile returns size bytes that differ from one offset to the next
+func testFile(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+func TestUploadFileSendsChunks(t *testing.T) {
+	clt, chunks := newUploadServer(t)
+	data := testFile(12 << 20)
+
+	var progress []UploadProgress
+	options := UploadOptions{Progress: func(p UploadProgress) { progress = append(progress, p) }}
+	params := map[string]interface{}{"fileId": "unique()"}
+	result, err := clt.UploadFile("/storage/buckets/b/files", nil, params, "file", NewInputFile("a.bin", bytes.NewReader(data)), int64(len(data)), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["$id"] != "upload" {
+		t.Fatalf("got %v", result)
+	}
+
+	got := chunks()
+	ranges := []string{"bytes 0-5242879/12582912", "bytes 5242880-10485759/12582912", "bytes 10485760-12582911/12582912"}
+	ids := []string{"", "upload", "upload"}
+	if len(got) != len(ranges) {
+		t.Fatalf("sent %d chunks", len(got))
+	}
+	var sent []byte
+	for i, chunk := range got {
+		if chunk.contentRange != ranges[i] || chunk.uploadId != ids[i] {
+			t.Fatalf("chunk %d sent range %q and upload id %q", i, chunk.contentRange, chunk.uploadId)
+		}
+		sent = append(sent, chunk.data...)
+	}
+	if !bytes.Equal(sent, data) {
+		t.Fatal("the chunks don't add up to the file")
+	}
+
+	uploaded := []int64{5 << 20, 10 << 20, 12 << 20}
+	for i, p := range progress {
+		if p.Uploaded != uploaded[i] || p.Total != int64(len(data)) {
+			t.Fatalf("got progress %+v", progress)
+		}
+	}
+	if len(progress) != len(uploaded) {
+		t.Fatalf("got progress %+v", progress)
+	}
+}
+
+func TestUploadFileSendsSmallFilesAtOnce(t *testing.T) {
+	clt, chunks := newUploadServer(t)
+	data := testFile(1024)
+
+	if _, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", NewInputFile("a.bin", bytes.NewReader(data)), 0, UploadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	got := chunks()
+	if len(got) != 1 || got[0].contentRange != "" || !bytes.Equal(got[0].data, data) {
+		t.Fatalf("sent %d chunks", len(got))
+	}
+}
+
+func TestUploadFileSizesSeekers(t *testing.T) {
+	clt, chunks := newUploadServer(t)
+	data := testFile(10 << 20)
+
+	if _, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", NewInputFile("a.bin", bytes.NewReader(data)), 0, UploadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	got := chunks()
+	ranges := []string{"bytes 0-5242879/10485760", "bytes 5242880-10485759/10485760"}
+	if len(got) != len(ranges) {
+		t.Fatalf("sent %d chunks", len(got))
+	}
+	for i, chunk := range got {
+		if chunk.contentRange != ranges[i] {
+			t.Fatalf("chunk %d sent range %q", i, chunk.contentRange)
+		}
+	}
+}
+
+func TestUploadFileBuffersPlainReaders(t *testing.T) {
+	clt, chunks := newUploadServer(t)
+	var warnings []string
+	clt.SetLogger(func(message string) {
+		if strings.HasPrefix(message, "warning") {
+			warnings = append(warnings, message)
+		}
+	})
+
+	file := NewInputFile("a.bin", io.LimitReader(strings.NewReader("hello"), 5))
+	if _, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", file, 0, UploadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := chunks(); len(got) != 1 || string(got[0].data) != "hello" {
+		t.Fatalf("sent %d chunks", len(got))
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("logged warnings %q", warnings)
+	}
+}
+
+func TestUploadFileResumesFromMidpoint(t *testing.T) {
+	clt, chunks := newUploadServer(t)
+	data := testFile(3 * chunkSize)
+
+	var progress []UploadProgress
+	options := UploadOptions{
+		Resume:   &UploadProgress{UploadId: "upload", Uploaded: chunkSize},
+		Progress: func(p UploadProgress) { progress = append(progress, p) },
+	}
+	file := NewInputFile("a.bin", bytes.NewReader(data))
+	if _, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", file, int64(len(data)), options); err != nil {
+		t.Fatal(err)
+	}
+
+	got := chunks()
+	if len(got) != 2 {
+		t.Fatalf("sent %d chunks", len(got))
+	}
+	if got[0].contentRange != "bytes 5242880-10485759/15728640" || got[1].contentRange != "bytes 10485760-15728639/15728640" {
+		t.Fatalf("sent ranges %q and %q", got[0].contentRange, got[1].contentRange)
+	}
+	for i, chunk := range got {
+		if chunk.uploadId != "upload" {
+			t.Fatalf("chunk %d sent upload id %q", i, chunk.uploadId)
+		}
+		if !bytes.Equal(chunk.data, data[(i+1)*chunkSize:(i+2)*chunkSize]) {
+			t.Fatalf("chunk %d holds the wrong bytes", i)
+		}
+	}
+	if len(progress) != 2 || progress[1].Uploaded != int64(len(data)) || progress[1].UploadId != "upload" {
+		t.Fatalf("got progress %+v", progress)
+	}
+}
+
+func TestUploadFileResumesFromReaderOffset(t *testing.T) {
+	clt, chunks := newUploadServer(t)
+	header := []byte("header")
+	data := testFile(2 * chunkSize)
+
+	// The reader is positioned after a header the caller already consumed,
+	// so the size and the resumed bytes are counted from there
+	reader := bytes.NewReader(append(header, data...))
+	if _, err := reader.Seek(int64(len(header)), io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	options := UploadOptions{Resume: &UploadProgress{UploadId: "upload", Uploaded: chunkSize}}
+	if _, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", NewInputFile("a.bin", reader), 0, options); err != nil {
+		t.Fatal(err)
+	}
+
+	got := chunks()
+	if len(got) != 1 || !bytes.Equal(got[0].data, data[chunkSize:]) {
+		t.Fatalf("sent %d chunks", len(got))
+	}
+}
+
+func TestUploadFileCancelledAfterFirstChunk(t *testing.T) {
+	clt, chunks := newUploadServer(t)
+	data := testFile(3 * chunkSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	options := UploadOptions{Progress: func(UploadProgress) { cancel() }}
+	file := NewInputFile("a.bin", bytes.NewReader(data))
+	_, err := clt.UploadFileWithContext(ctx, "/storage/buckets/b/files", nil, nil, "file", file, int64(len(data)), options)
+
+	var uploadErr *UploadError
+	if !errors.As(err, &uploadErr) || uploadErr.UploadId != "upload" || uploadErr.Uploaded != chunkSize {
+		t.Fatalf("got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v", err)
+	}
+	if len(chunks()) != 1 {
+		t.Fatalf("sent %d chunks", len(chunks()))
+	}
+}
+
+func TestUploadFileAutoCleanupOnCancel(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		io.Copy(io.Discard, r.Body)
+		writeJSON(w, http.StatusCreated, `{"$id":"upload"}`)
+	})
+	data := testFile(3 * chunkSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	options := UploadOptions{AutoCleanupOnCancel: true, Progress: func(UploadProgress) { cancel() }}
+	file := NewInputFile("a.bin", bytes.NewReader(data))
+	_, err := clt.UploadFileWithContext(ctx, "/storage/buckets/b/files", nil, nil, "file", file, int64(len(data)), options)
+
+	var uploadErr *UploadError
+	if !errors.As(err, &uploadErr) || uploadErr.UploadId != "upload" || !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(deleted) != 1 || deleted[0] != "/v1/storage/buckets/b/files/upload" {
+		t.Fatalf("deleted %q", deleted)
+	}
+}
+
+// failingReader returns err once its data is read
+type failingReader struct {
+	data []byte
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestUploadFileReadErrorKeepsUploadId(t *testing.T) {
+	clt, _ := newUploadServer(t)
+	readErr := errors.New("disk failure")
+
+	file := NewInputFile("a.bin", &failingReader{data: testFile(chunkSize + 1), err: readErr})
+	_, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", file, 2*chunkSize, UploadOptions{})
+
+	var uploadErr *UploadError
+	if !errors.As(err, &uploadErr) || uploadErr.UploadId != "upload" || !errors.Is(err, readErr) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestUploadFileVerifyIntegrity(t *testing.T) {
+	data := testFile(6 << 20)
+	sum := md5.Sum(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	var mu sync.Mutex
+	signature := checksum
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		writeJSON(w, http.StatusCreated, fmt.Sprintf(`{"$id":"f","signature":%q}`, signature))
+	})
+	options := UploadOptions{VerifyIntegrity: true}
+
+	if _, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", NewInputFile("a.bin", bytes.NewReader(data)), 0, options); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	signature = "d41d8cd98f00b204e9800998ecf8427e"
+	mu.Unlock()
+	result, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", NewInputFile("a.bin", bytes.NewReader(data)), 0, options)
+	if !errors.Is(err, ErrIntegrityMismatch) {
+		t.Fatalf("got %v", err)
+	}
+	if result["$id"] != "f" {
+		t.Fatalf("the uploaded file was not returned: %v", result)
+	}
+
+	if _, err := clt.UploadFile("/storage/buckets/b/files", nil, nil, "file", NewInputFile("a.bin", bytes.NewReader(data)), 0, UploadOptions{}); err != nil {
+		t.Fatalf("verified without VerifyIntegrity: %v", err)
+	}
+}
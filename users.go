@@ -1,6 +1,7 @@
 package appwrite
 
 import (
+	"net/url"
 	"strings"
 )
 
@@ -48,7 +49,7 @@ func (srv *Users) Create(Email string, Password string, Name string, userId stri
 
 // Get get user by its unique ID.
 func (srv *Users) Get(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
 	path := r.Replace("/users/{userId}")
 
 	params := map[string]interface{}{}
@@ -58,7 +59,7 @@ func (srv *Users) Get(UserId string) (map[string]interface{}, error) {
 
 // GetLogs get user activity logs list by its unique ID.
 func (srv *Users) GetLogs(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
 	path := r.Replace("/users/{userId}/logs")
 
 	params := map[string]interface{}{}
@@ -68,7 +69,7 @@ func (srv *Users) GetLogs(UserId string) (map[string]interface{}, error) {
 
 // GetPrefs get user preferences by its unique ID.
 func (srv *Users) GetPrefs(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
 	path := r.Replace("/users/{userId}/prefs")
 
 	params := map[string]interface{}{}
@@ -79,7 +80,7 @@ func (srv *Users) GetPrefs(UserId string) (map[string]interface{}, error) {
 // UpdatePrefs update user preferences by its unique ID. You can pass only the
 // specific settings you wish to update.
 func (srv *Users) UpdatePrefs(UserId string, Prefs interface{}) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
 	path := r.Replace("/users/{userId}/prefs")
 
 	params := map[string]interface{}{
@@ -90,8 +91,10 @@ func (srv *Users) UpdatePrefs(UserId string, Prefs interface{}) (map[string]inte
 }
 
 // GetSessions get user sessions list by its unique ID.
+//
+// Deprecated: use ListSessions instead.
 func (srv *Users) GetSessions(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
 	path := r.Replace("/users/{userId}/sessions")
 
 	params := map[string]interface{}{}
@@ -101,7 +104,7 @@ func (srv *Users) GetSessions(UserId string) (map[string]interface{}, error) {
 
 // DeleteSessions delete all user sessions by its unique ID.
 func (srv *Users) DeleteSessions(UserId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
 	path := r.Replace("/users/{userId}/sessions")
 
 	params := map[string]interface{}{}
@@ -111,7 +114,7 @@ func (srv *Users) DeleteSessions(UserId string) (map[string]interface{}, error)
 
 // DeleteSession delete user sessions by its unique ID.
 func (srv *Users) DeleteSession(UserId string, SessionId string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
 	path := r.Replace("/users/{userId}/sessions/:session")
 
 	params := map[string]interface{}{
@@ -123,7 +126,7 @@ func (srv *Users) DeleteSession(UserId string, SessionId string) (map[string]int
 
 // UpdateStatus update user status by its unique ID.
 func (srv *Users) UpdateStatus(UserId string, Status string) (map[string]interface{}, error) {
-	r := strings.NewReplacer("{userId}", UserId)
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
 	path := r.Replace("/users/{userId}/status")
 
 	params := map[string]interface{}{
@@ -132,3 +135,65 @@ func (srv *Users) UpdateStatus(UserId string, Status string) (map[string]interfa
 
 	return srv.client.Call("PATCH", path, nil, params)
 }
+
+// CreateBcryptUser create a new user. Password provided must be hashed with
+// the [Bcrypt](https://en.wikipedia.org/wiki/Bcrypt) algorithm.
+func (srv *Users) CreateBcryptUser(UserId string, Email string, Password string, Name string) (map[string]interface{}, error) {
+	path := "/users/bcrypt"
+
+	params := map[string]interface{}{
+		"userId":   UserId,
+		"email":    Email,
+		"password": Password,
+		"name":     Name,
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// CreateArgon2User create a new user. Password provided must be hashed with
+// the [Argon2](https://en.wikipedia.org/wiki/Argon2) algorithm.
+func (srv *Users) CreateArgon2User(UserId string, Email string, Password string, Name string) (map[string]interface{}, error) {
+	path := "/users/argon2"
+
+	params := map[string]interface{}{
+		"userId":   UserId,
+		"email":    Email,
+		"password": Password,
+		"name":     Name,
+	}
+
+	return srv.client.Call("POST", path, nil, params)
+}
+
+// Delete delete a user by its unique ID, thereby releasing it's ID.
+func (srv *Users) Delete(UserId string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
+	path := r.Replace("/users/{userId}")
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("DELETE", path, nil, params)
+}
+
+// UpdateEmail update the user email by its unique ID.
+func (srv *Users) UpdateEmail(UserId string, Email string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
+	path := r.Replace("/users/{userId}/email")
+
+	params := map[string]interface{}{
+		"email": Email,
+	}
+
+	return srv.client.Call("PATCH", path, nil, params)
+}
+
+// ListSessions get the user sessions list by its unique ID.
+func (srv *Users) ListSessions(UserId string) (map[string]interface{}, error) {
+	r := strings.NewReplacer("{userId}", url.PathEscape(UserId))
+	path := r.Replace("/users/{userId}/sessions")
+
+	params := map[string]interface{}{}
+
+	return srv.client.Call("GET", path, nil, params)
+}
@@ -0,0 +1,56 @@
+package appwrite
+
+import "testing"
+
+func TestUsersCreateArgon2User(t *testing.T) {
+	clt, last := recordRequests(t, `{"$id":"u1"}`)
+	srv := NewUsers(clt)
+
+	hash := "$argon2id$v=19$m=2048,t=3,p=3$c2FsdA$aGFzaA"
+	user, err := srv.CreateArgon2User("u1", "a@b.c", hash, "Ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user["$id"] != "u1" {
+		t.Fatalf("got user %v", user)
+	}
+
+	req := last()
+	if req.Method != "POST" || req.Path != "/v1/users/argon2" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if req.Body["password"] != hash || req.Body["userId"] != "u1" || req.Body["email"] != "a@b.c" {
+		t.Fatalf("sent %v", req.Body)
+	}
+}
+
+func TestUsersPaths(t *testing.T) {
+	clt, last := recordRequests(t, `{}`)
+	srv := NewUsers(clt)
+
+	tests := []struct {
+		name   string
+		call   func() (map[string]interface{}, error)
+		method string
+		path   string
+	}{
+		{"List", func() (map[string]interface{}, error) { return srv.List("", 0, 0, "") }, "GET", "/v1/users"},
+		{"Create", func() (map[string]interface{}, error) { return srv.Create("a@b.c", "secret", "Ada", "u1") }, "POST", "/v1/users"},
+		{"CreateBcryptUser", func() (map[string]interface{}, error) {
+			return srv.CreateBcryptUser("u1", "a@b.c", "$2a$10$hash", "Ada")
+		}, "POST", "/v1/users/bcrypt"},
+		{"Get", func() (map[string]interface{}, error) { return srv.Get("u/1") }, "GET", "/v1/users/u%2F1"},
+		{"Delete", func() (map[string]interface{}, error) { return srv.Delete("u/1") }, "DELETE", "/v1/users/u%2F1"},
+		{"UpdateEmail", func() (map[string]interface{}, error) { return srv.UpdateEmail("u/1", "a@b.c") }, "PATCH", "/v1/users/u%2F1/email"},
+		{"UpdateStatus", func() (map[string]interface{}, error) { return srv.UpdateStatus("u/1", "true") }, "PATCH", "/v1/users/u%2F1/status"},
+		{"ListSessions", func() (map[string]interface{}, error) { return srv.ListSessions("u/1") }, "GET", "/v1/users/u%2F1/sessions"},
+	}
+	for _, test := range tests {
+		if _, err := test.call(); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if req := last(); req.Method != test.method || req.Path != test.path {
+			t.Errorf("%s: sent %s %s, want %s %s", test.name, req.Method, req.Path, test.method, test.path)
+		}
+	}
+}
@@ -1,13 +1,19 @@
 package appwrite
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 )
 
-// ToString changes arg to string
+// ToString changes arg to string. Nil values, including nil pointers, give
+// an empty string and time.Time values are formatted as RFC3339
 func ToString(arg interface{}) string {
+	if isNil(arg) {
+		return ""
+	}
 	var tmp = reflect.Indirect(reflect.ValueOf(arg)).Interface()
 	switch v := tmp.(type) {
 	case int:
@@ -20,8 +26,24 @@ func ToString(arg interface{}) string {
 		return strconv.FormatInt(int64(v), 10)
 	case int64:
 		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
 	case string:
 		return v
+	case json.Number:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339)
 	case float32:
 		return strconv.FormatFloat(float64(v), 'f', -1, 32)
 	case float64:
@@ -34,3 +56,39 @@ func ToString(arg interface{}) string {
 		return ""
 	}
 }
+
+// isNil reports whether arg is nil or a nil pointer, map, slice or interface
+func isNil(arg interface{}) bool {
+	if arg == nil {
+		return true
+	}
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
+
+// NullValue is the type of Null
+type NullValue struct{}
+
+// MarshalJSON encodes NullValue as JSON null
+func (NullValue) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+// Null sends an explicit JSON null for a param. Params set to a plain nil are
+// left out of request bodies instead
+var Null = NullValue{}
+
+// omitNilParams returns params without the nil values, keeping Null
+func omitNilParams(params map[string]interface{}) map[string]interface{} {
+	kept := make(map[string]interface{}, len(params))
+	for key, val := range params {
+		if !isNil(val) {
+			kept[key] = val
+		}
+	}
+	return kept
+}
@@ -0,0 +1,79 @@
+package appwrite
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestToString(t *testing.T) {
+	n := 7
+	tests := []struct {
+		arg  interface{}
+		want string
+	}{
+		{nil, ""},
+		{(*int)(nil), ""},
+		{42, "42"},
+		{int64(9007199254740993), "9007199254740993"},
+		{uint8(200), "200"},
+		{1.5, "1.5"},
+		{1e6, "1000000"},
+		{float32(0.25), "0.25"},
+		{true, "true"},
+		{false, "false"},
+		{"text", "text"},
+		{&n, "7"},
+		{json.Number("12.50"), "12.50"},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "2024-01-02T03:04:05Z"},
+	}
+	for _, test := range tests {
+		if got := ToString(test.arg); got != test.want {
+			t.Errorf("ToString(%#v) = %q, want %q", test.arg, got, test.want)
+		}
+	}
+}
+
+func TestNumbersAndBoolsInQueryAndBody(t *testing.T) {
+	var query, body string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		query, body = r.URL.RawQuery, string(data)
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+	params := map[string]interface{}{"count": int64(3), "ratio": 1.5, "big": 1e6, "active": true}
+
+	clt.Call("GET", "/things", nil, params)
+	if query != "active=true&big=1000000&count=3&ratio=1.5" {
+		t.Fatalf("sent query %q", query)
+	}
+
+	clt.Call("POST", "/things", nil, params)
+	if body != `{"active":true,"big":1000000,"count":3,"ratio":1.5}` {
+		t.Fatalf("sent body %q", body)
+	}
+}
+
+func TestNilParamsOmittedFromBody(t *testing.T) {
+	var body string
+	clt := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	params := map[string]interface{}{
+		"name":     "Ada",
+		"email":    nil,
+		"phone":    (*string)(nil),
+		"password": Null,
+	}
+	if _, err := clt.Call("PATCH", "/users/u1", nil, params); err != nil {
+		t.Fatal(err)
+	}
+	if body != `{"name":"Ada","password":null}` {
+		t.Fatalf("sent body %q", body)
+	}
+}
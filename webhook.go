@@ -0,0 +1,32 @@
+package appwrite
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+)
+
+// VerifyWebhookSignature checks the X-Appwrite-Webhook-Signature header of a
+// webhook delivery. Appwrite signs deliveries with the base64 encoded
+// HMAC-SHA1 of the webhook endpoint URL followed by the payload, keyed with
+// the webhook signature key
+func VerifyWebhookSignature(endpoint string, payload []byte, signatureHeader string, signatureKey string) (bool, error) {
+	if signatureHeader == "" {
+		return false, errors.New("missing webhook signature")
+	}
+	if signatureKey == "" {
+		return false, errors.New("missing webhook signature key")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signatureHeader)
+	if err != nil {
+		return false, errors.New("malformed webhook signature: " + err.Error())
+	}
+
+	mac := hmac.New(sha1.New, []byte(signatureKey))
+	mac.Write([]byte(endpoint))
+	mac.Write(payload)
+
+	return hmac.Equal(signature, mac.Sum(nil)), nil
+}
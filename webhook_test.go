@@ -0,0 +1,48 @@
+package appwrite
+
+import (
+	"testing"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	endpoint := "https://example.com/webhook"
+	payload := []byte(`{"$id":"u1"}`)
+	signature := "ymqFgut4AR917La0N33hPJEeVnI="
+
+	if ok, err := VerifyWebhookSignature(endpoint, payload, signature, "secret"); !ok || err != nil {
+		t.Fatalf("rejected a valid signature: %v", err)
+	}
+
+	mismatches := []struct {
+		name     string
+		endpoint string
+		payload  []byte
+		key      string
+	}{
+		{"payload", endpoint, []byte(`{"$id":"u2"}`), "secret"},
+		{"endpoint", "https://example.com/other", payload, "secret"},
+		{"key", endpoint, payload, "other"},
+	}
+	for _, test := range mismatches {
+		if ok, err := VerifyWebhookSignature(test.endpoint, test.payload, signature, test.key); ok || err != nil {
+			t.Errorf("another %s got %v, %v", test.name, ok, err)
+		}
+	}
+}
+
+func TestVerifyWebhookSignatureInvalidInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		key       string
+	}{
+		{"missing signature", "", "secret"},
+		{"missing key", "ymqFgut4AR917La0N33hPJEeVnI=", ""},
+		{"malformed signature", "not base64!", "secret"},
+	}
+	for _, test := range tests {
+		if ok, err := VerifyWebhookSignature("https://example.com/webhook", nil, test.signature, test.key); ok || err == nil {
+			t.Errorf("%s: got %v, %v", test.name, ok, err)
+		}
+	}
+}